@@ -0,0 +1,11 @@
+// 14 october 2026
+
+package dl
+
+// AddrInfo describes where an address is, as returned by Addr.
+type AddrInfo struct {
+	Path		string		// the object containing the address
+	Base		uintptr		// the address the object is loaded at
+	Symbol	string		// the nearest symbol at or before the address, or an empty string if there is none
+	SymbolAddr	uintptr		// the address of Symbol, or 0 if there is none
+}
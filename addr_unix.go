@@ -0,0 +1,37 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+import "C"
+
+// Addr finds the loaded object, and the symbol within it, that contains ptr.
+// This is dladdr(); it is what you want to find out which library really provided a symbol, or to name the addresses in a stack trace.
+// Only symbols in the dynamic symbol table are known, so Symbol may be empty or (for a static function) the name of some other function.
+func Addr(ptr unsafe.Pointer) (*AddrInfo, error) {
+	var info C.Dl_info
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if C.dladdr(ptr, &info) == 0 {
+		return nil, fmt.Errorf("dl: %p is not in any loaded object", ptr)
+	}
+	a := &AddrInfo{
+		Path:		C.GoString(info.dli_fname),
+		Base:		uintptr(info.dli_fbase),
+		SymbolAddr:	uintptr(info.dli_saddr),
+	}
+	if info.dli_sname != nil {
+		a.Symbol = C.GoString(info.dli_sname)
+	}
+	return a, nil
+}
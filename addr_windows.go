@@ -0,0 +1,49 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	getModuleHandleExFlagFromAddress = 0x4		// GET_MODULE_HANDLE_EX_FLAG_FROM_ADDRESS
+	getModuleHandleExFlagUnchangedRefcount = 0x2	// GET_MODULE_HANDLE_EX_FLAG_UNCHANGED_REFCOUNT
+)
+
+var getModuleFileNameW = kernel32.NewProc("GetModuleFileNameW")
+
+// Addr finds the loaded DLL that contains ptr.
+// Windows keeps no symbol information at run time, so Symbol and SymbolAddr are always empty.
+func Addr(ptr unsafe.Pointer) (*AddrInfo, error) {
+	var h syscall.Handle
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	r, _, err := getModuleHandleExW.Call(getModuleHandleExFlagFromAddress | getModuleHandleExFlagUnchangedRefcount,
+		uintptr(ptr), uintptr(unsafe.Pointer(&h)))
+	if r == 0 {
+		return nil, fmt.Errorf("dl: %p is not in any loaded DLL: %w", ptr, err)
+	}
+	path, err := Module(h).path()
+	if err != nil {
+		return nil, err
+	}
+	return &AddrInfo{
+		Path:	path,
+		Base:	uintptr(h),		// an HMODULE is the DLL's base address
+	}, nil
+}
+
+// path returns the file m was loaded from.
+func (m Module) path() (string, error) {
+	buf := make([]uint16, syscall.MAX_LONG_PATH)
+	n, _, err := getModuleFileNameW.Call(uintptr(m), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if n == 0 {
+		return "", err
+	}
+	return syscall.UTF16ToString(buf[:n]), nil
+}
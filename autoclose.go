@@ -0,0 +1,44 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"runtime"
+	"sync"
+)
+
+// AutoModule is a Module that closes itself when the garbage collector finds it unreachable, in case it is never closed explicitly.
+// The Module methods are available on it directly.
+//
+// Because the garbage collector only knows about the *AutoModule, not about any symbols or copies of the Module taken from it, you must keep the *AutoModule reachable (for instance, with runtime.KeepAlive) for as long as you use anything from the library.
+// Otherwise the library may be unloaded out from under you.
+type AutoModule struct {
+	Module
+
+	once	sync.Once
+	err		error
+}
+
+// OpenAutoClose is like Open, but returns an *AutoModule, which will close the library when it becomes unreachable.
+// This is a safety net for long-running programs, not a replacement for Close: there's no telling when, or even whether, the finalizer will run.
+func OpenAutoClose(name string, mode Mode) (*AutoModule, error) {
+	m, err := Open(name, mode)
+	if err != nil {
+		return nil, err
+	}
+	a := &AutoModule{
+		Module:	m,
+	}
+	runtime.SetFinalizer(a, (*AutoModule).Close)
+	return a, nil
+}
+
+// Close closes the library now, and stops the finalizer from closing it again.
+// Calling Close more than once returns the result of the first call.
+func (a *AutoModule) Close() error {
+	a.once.Do(func() {
+		runtime.SetFinalizer(a, nil)
+		a.err = a.Module.Close()
+	})
+	return a.err
+}
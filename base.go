@@ -0,0 +1,41 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+)
+
+// BaseAddress returns the address m is loaded at: on ELF systems, the difference between addresses in the file and addresses in memory (which for a shared object is where its first byte is mapped); on OS X, the address of its Mach-O header; and on Windows, the HMODULE itself, which is where the DLL's image begins.
+// Subtracting it from an address in m gives the file-relative offset that symbolizers such as addr2line, atos -l, and the Windows debuggers expect; see also SymbolOffset.
+// On Linux this comes from the link map; elsewhere on Unix, dladdr() needs an address in m to work from, so it only works once a symbol defined in m has been looked up through this package.
+func (m Module) BaseAddress() (uintptr, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.BaseAddress)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+	return m.base()
+}
+
+// SymbolOffset looks up the given named symbol in m and returns its address relative to BaseAddress: its value in the file's symbol table on ELF systems, and its RVA on Windows.
+// The symbol must be defined in m itself, not in one of its dependencies.
+// Unlike the address itself, this is the same in every process that loads the library, so it can be recorded for crash symbolication or patching.
+func (m Module) SymbolOffset(name string) (uintptr, error) {
+	s, err := m.Symbol(name)
+	if err != nil {
+		return 0, err
+	}
+	if s == nil {
+		return 0, fmt.Errorf("dl: symbol %s has a nil address, which has no offset", name)
+	}
+	base, err := m.BaseAddress()
+	if err != nil {
+		return 0, err
+	}
+	// a symbol found through a dependency would give a meaningless offset
+	if a, err := Addr(s); err == nil && a.Base != base {
+		return 0, fmt.Errorf("dl: symbol %s is defined in %s, not in the library itself", name, a.Path)
+	}
+	return uintptr(s) - base, nil
+}
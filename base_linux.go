@@ -0,0 +1,13 @@
+// 14 october 2026
+
+package dl
+
+// base returns the address m is loaded at, from its link map entry; this is the same as dl_iterate_phdr()'s dlpi_addr.
+// The caller must hold dllock.
+func (m Module) base() (uintptr, error) {
+	o, err := m.object()
+	if err != nil {
+		return 0, err
+	}
+	return o.bias, nil
+}
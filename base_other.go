@@ -0,0 +1,21 @@
+// 14 october 2026
+
+//go:build unix && !linux
+
+package dl
+
+// #include <dlfcn.h>
+import "C"
+
+// base returns the address m is loaded at, which is what dladdr() gives as dli_fbase for any symbol in m.
+// The caller must hold dllock.
+func (m Module) base() (uintptr, error) {
+	if err := m.closedError("info", ""); err != nil {
+		return 0, err
+	}
+	info, err := m.ownSymbol()
+	if err != nil {
+		return 0, err
+	}
+	return uintptr(info.dli_fbase), nil
+}
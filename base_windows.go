@@ -0,0 +1,12 @@
+// 14 october 2026
+
+package dl
+
+// base returns the address m is loaded at; an HMODULE is the address of the DLL's image.
+// The caller must hold dllock.
+func (m Module) base() (uintptr, error) {
+	if err := m.closedError("info", ""); err != nil {
+		return 0, err
+	}
+	return uintptr(m), nil
+}
@@ -0,0 +1,56 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+var pointerType = reflect.TypeOf(unsafe.Pointer(nil))
+
+// Bind fills in the fields of the struct pointed to by v with symbols from m.
+// Each field to fill must be of type unsafe.Pointer or uintptr and have a tag naming the symbol:
+// 	var av struct {
+// 		FrameAlloc	unsafe.Pointer	`dl:"av_frame_alloc"`
+// 		FrameFree	unsafe.Pointer	`dl:"av_frame_free"`
+// 	}
+// 	err := dl.Bind(m, &av)
+// Fields without a dl tag are left alone.
+// Bind looks up every tagged field even if some fail; the returned error lists all the symbols that could not be found, and the fields for those are left alone.
+func Bind(m Module, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dl: Bind needs a pointer to a struct, not %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs []error
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		name, ok := f.Tag.Lookup("dl")
+		if !ok {
+			continue
+		}
+		if f.Type != pointerType && f.Type.Kind() != reflect.Uintptr {
+			return fmt.Errorf("dl: field %s of %s is %s, not unsafe.Pointer or uintptr", f.Name, rt, f.Type)
+		}
+		if !f.IsExported() {
+			return fmt.Errorf("dl: field %s of %s is not exported", f.Name, rt)
+		}
+		s, err := m.Symbol(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if f.Type == pointerType {
+			rv.Field(i).SetPointer(s)
+		} else {
+			rv.Field(i).SetUint(uint64(uintptr(s)))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+const bindSource = "int first(void) { return 1; }\nint second(void) { return 2; }\n"
+
+func openBindLibrary(t *testing.T) dl.Module {
+	t.Helper()
+	lib := dltest.Build(t, bindSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	t.Cleanup(func() {
+		m.Close()
+	})
+	return m
+}
+
+func TestBind(t *testing.T) {
+	m := openBindLibrary(t)
+	var syms struct {
+		First	unsafe.Pointer	`dl:"first"`
+		Second	uintptr		`dl:"second"`
+		Other	unsafe.Pointer
+	}
+	if err := dl.Bind(m, &syms); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if want, _ := m.Symbol("first"); syms.First != want {
+		t.Errorf("First = %p; want %p", syms.First, want)
+	}
+	if want, _ := m.Symbol("second"); syms.Second != uintptr(want) {
+		t.Errorf("Second = %#x; want %p", syms.Second, want)
+	}
+	if syms.Other != nil {
+		t.Errorf("untagged field Other = %p; want it left alone", syms.Other)
+	}
+}
+
+func TestBindMissing(t *testing.T) {
+	m := openBindLibrary(t)
+	var syms struct {
+		First	unsafe.Pointer	`dl:"first"`
+		Missing1	unsafe.Pointer	`dl:"dltest_missing1"`
+		Missing2	uintptr		`dl:"dltest_missing2"`
+	}
+	err := dl.Bind(m, &syms)
+	if err == nil {
+		t.Fatalf("Bind with missing symbols succeeded")
+	}
+	if !errors.Is(err, dl.ErrSymbolNotFound) {
+		t.Errorf("Bind error = %v; want it to wrap ErrSymbolNotFound", err)
+	}
+	for _, name := range []string{"dltest_missing1", "dltest_missing2"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Bind error %q does not name %s", err, name)
+		}
+	}
+	if syms.First == nil {
+		t.Errorf("First was not bound alongside the missing symbols")
+	}
+	if syms.Missing1 != nil || syms.Missing2 != 0 {
+		t.Errorf("fields for missing symbols were changed")
+	}
+}
+
+func TestBindInvalid(t *testing.T) {
+	m := openBindLibrary(t)
+	var notPointer struct {
+		First	unsafe.Pointer	`dl:"first"`
+	}
+	var wrongType struct {
+		First	int	`dl:"first"`
+	}
+	var unexported struct {
+		first	unsafe.Pointer	`dl:"first"`
+	}
+	for _, v := range []interface{}{notPointer, &wrongType, &unexported, new(int), nil} {
+		if err := dl.Bind(m, v); err == nil {
+			t.Errorf("Bind(%T) succeeded", v)
+		}
+	}
+	_ = unexported.first
+}
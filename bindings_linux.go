@@ -0,0 +1,96 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// #ifndef __GLIBC__
+// #define dlvsym(h, n, v) dlsym(h, n)
+// #endif
+// /* done in one call so the dlerror() is from the same thread as the dlsym() */
+// static int bound(void *handle, const char *name, const char *version)
+// {
+// 	void *sym;
+//
+// 	dlerror();
+// 	if (version != NULL)
+// 		sym = dlvsym(handle, name, version);
+// 	else
+// 		sym = dlsym(handle, name);
+// 	return sym != NULL || dlerror() == NULL;
+// }
+// static int boundanywhere(void *handle, const char *name, const char *version)
+// {
+// 	return bound(handle, name, version) || bound(RTLD_DEFAULT, name, version);
+// }
+import "C"
+
+// CheckBindings returns the symbols m needs from other objects that cannot be found, which are the ones that would make a program crash when first used if m was opened with Lazy.
+// Each symbol is looked up the way the dynamic linker would bind it: first in m's dependencies, then in every object loaded with Global.
+// Versioned symbols are listed as name@version; weak references, which are allowed to stay unbound, are not checked.
+// The returned list is empty if everything is bound.
+// This reads the dynamic symbol table of the file m was loaded from, so it only works where Info does; elsewhere it returns ErrUnsupported.
+// (Windows binds every import when a DLL is loaded, so there is nothing to check there.)
+func (m Module) CheckBindings() ([]string, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.CheckBindings)
+	}
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	f, err := elf.Open(o.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	syms, err := f.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	dllock.RLock()
+	defer dllock.RUnlock()
+
+	if err := m.closedError("info", ""); err != nil {		// closed while the file was being read
+		return nil, err
+	}
+	unbound := []string{}
+	seen := make(map[string]bool)
+	for _, s := range syms {
+		if s.Section != elf.SHN_UNDEF || s.Name == "" || elf.ST_BIND(s.Info) == elf.STB_WEAK {
+			continue
+		}
+		name := s.Name
+		if s.Version != "" {
+			name += "@" + s.Version
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if !bound(m, s.Name, s.Version) {
+			unbound = append(unbound, name)
+		}
+	}
+	return unbound, nil
+}
+
+func bound(m Module, name string, version string) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	var cversion *C.char
+	if version != "" {
+		cversion = C.CString(version)
+		defer C.free(unsafe.Pointer(cversion))
+	}
+	return C.boundanywhere(unsafe.Pointer(m), cname, cversion) != 0
+}
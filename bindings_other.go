@@ -0,0 +1,12 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// CheckBindings returns the symbols m needs from other objects that cannot be found.
+// It needs to read m's dynamic symbol table, which is only possible on Linux, so it returns ErrUnsupported here.
+// (Windows binds every import when a DLL is loaded, so there is nothing to check there.)
+func (m Module) CheckBindings() ([]string, error) {
+	return nil, ErrUnsupported
+}
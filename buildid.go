@@ -0,0 +1,95 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"encoding/hex"
+	"io"
+)
+
+// elfFile opens the ELF file m was loaded from.
+func (m Module) elfFile() (*elf.File, error) {
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return elf.Open(o.path)
+}
+
+// SOName returns m's soname (its DT_SONAME entry): the name other objects record when they are linked against it, such as "libc.so.6".
+// It is empty if m has none, which is normal for plugins and for the main program.
+// Like Dependencies, this only works on ELF systems.
+func (m Module) SOName() (string, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.SOName)
+	}
+	f, err := m.elfFile()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	names, err := f.DynString(elf.DT_SONAME)
+	if err != nil || len(names) == 0 {
+		return "", err
+	}
+	return names[0], nil
+}
+
+// BuildID returns m's GNU build ID, in hexadecimal as readelf and debuggers show it.
+// The build ID identifies the exact build of a file, so it's what symbol servers and crash reports use; it is empty if the file was linked without one.
+// Like Dependencies, this only works on ELF systems.
+func (m Module) BuildID() (string, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.BuildID)
+	}
+	f, err := m.elfFile()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_NOTE {
+			continue
+		}
+		b, err := io.ReadAll(p.Open())
+		if err != nil {
+			return "", err
+		}
+		if id := findNote(f, b, p.Align, "GNU", 3); id != nil {		// NT_GNU_BUILD_ID
+			return hex.EncodeToString(id), nil
+		}
+	}
+	return "", nil
+}
+
+// findNote returns the contents of the first note with the given name and type in b, the contents of a PT_NOTE segment aligned to align, or nil if there is none.
+func findNote(f *elf.File, b []byte, align uint64, name string, typ uint32) []byte {
+	if align < 4 {
+		align = 4
+	}
+	pad := func(n uint64) uint64 {
+		return (n + align - 1) &^ (align - 1)
+	}
+	for uint64(len(b)) >= 12 {
+		namesz := uint64(f.ByteOrder.Uint32(b[0:]))
+		descsz := uint64(f.ByteOrder.Uint32(b[4:]))
+		t := f.ByteOrder.Uint32(b[8:])
+		b = b[12:]
+		if pad(namesz) + descsz > uint64(len(b)) {
+			return nil
+		}
+		n := string(b[:namesz])
+		desc := b[pad(namesz):pad(namesz) + descsz]
+		if t == typ && (n == name + "\x00" || n == name) {
+			return desc
+		}
+		if pad(namesz) + pad(descsz) > uint64(len(b)) {
+			return nil
+		}
+		b = b[pad(namesz) + pad(descsz):]
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+// OpenBytes opens the library whose contents are data, without the caller having to write it to disk first.
+// On Linux the library is put in an anonymous memory file (memfd_create()) and never touches the disk; elsewhere, or if that fails, it is written to a new temporary file only the current user can read.
+// Either way, the copy is removed once the last reference to the returned Module is closed; a memory file whose library stays loaded after that (see SetLeakOnClose and Nodelete) is kept open instead, so that its name, which the dynamic linker knows the library by, is never reused for another.
+// As with Open, the Policy is checked, and SetValidate's check is made, before the copy is loaded.
+//
+// The library's dependencies are looked up as usual; since the copy has no directory of its own, $ORIGIN (and @loader_path on OS X) don't mean anything useful in it.
+func OpenBytes(data []byte, mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+	path, release, err := memfile(data)
+	if err != nil {
+		path, release, err = tempfile(data)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("dl: could not stage library for loading: %w", err)
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	start := time.Now()
+	if err := checkPolicy(path, path); err != nil {
+		release()
+		return finishOpen(path, mode, start, 0, err)
+	}
+	if validate {
+		if err := validateOpen(path); err != nil {
+			release()
+			return finishOpen(path, mode, start, 0, err)
+		}
+	}
+	m, err := sysopen(path, mode)
+	if err != nil {
+		release()
+		return finishOpen(path, mode, start, 0, openError(path, path, mode, err))
+	}
+	m, err = finishOpen(path, mode, start, m, nil)
+	if err != nil {
+		release()
+		return 0, err
+	}
+	onLastClose(m, release)
+	return m, nil
+}
+
+// tempfile writes data to a new temporary file and returns its path and a function that removes it.
+func tempfile(data []byte) (path string, release func(), err error) {
+	pattern := "dl-*.so"
+	if runtime.GOOS == "windows" {
+		pattern = "dl-*.dll"		// otherwise LoadLibrary() adds .dll itself
+	}
+	f, err := os.CreateTemp("", pattern)		// 0600, and never an existing file
+	if err != nil {
+		return "", nil, err
+	}
+	path = f.Name()
+	release = func() {
+		os.Remove(path)
+	}
+	_, err = f.Write(data)
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		release()
+		return "", nil, err
+	}
+	return path, release, nil
+}
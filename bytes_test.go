@@ -0,0 +1,84 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+// readLibrary builds source and returns the contents of the library.
+func readLibrary(t *testing.T, source string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(dltest.Build(t, source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestOpenBytes(t *testing.T) {
+	m, err := dl.OpenBytes(readLibrary(t, symbolSource), dl.Now)
+	if err != nil {
+		t.Fatalf("OpenBytes: %v", err)
+	}
+	if _, err := m.Symbol("answer"); err != nil {
+		t.Errorf("Symbol(answer): %v", err)
+	}
+	path, pathErr := m.Path()
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if pathErr == nil {
+		if loaded, err := dl.IsLoaded(path); err == nil && loaded {
+			t.Errorf("%s is still loaded after Close", path)
+		}
+	}
+}
+
+func TestOpenBytesValidate(t *testing.T) {
+	dl.SetValidate(true)
+	defer dl.SetValidate(false)
+	m, err := dl.OpenBytes([]byte("this is not a library, just some text"), dl.Now)
+	if err == nil {
+		m.Close()
+		t.Fatalf("OpenBytes of text succeeded")
+	}
+	var fe *dl.FormatError
+	if !errors.As(err, &fe) {
+		t.Errorf("OpenBytes of text error = %v; want a *FormatError from SetValidate", err)
+	}
+}
+
+// A library that stays loaded after Close must not be what a later OpenBytes gets.
+func TestOpenBytesOutlivesClose(t *testing.T) {
+	first := readLibrary(t, "int dltest_first(void) { return 1; }\n")
+	second := readLibrary(t, "int dltest_second(void) { return 2; }\n")
+
+	dl.SetLeakOnClose(true)
+	m, err := dl.OpenBytes(first, dl.Now)
+	if err != nil {
+		dl.SetLeakOnClose(false)
+		t.Fatalf("OpenBytes of the first library: %v", err)
+	}
+	err = m.Close()
+	dl.SetLeakOnClose(false)
+	if err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	m, err = dl.OpenBytes(second, dl.Now)
+	if err != nil {
+		t.Fatalf("OpenBytes of the second library: %v", err)
+	}
+	defer m.Close()
+	if _, err := m.Symbol("dltest_second"); err != nil {
+		t.Errorf("the second OpenBytes got another library: Symbol(dltest_second): %v", err)
+	}
+}
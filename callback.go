@@ -0,0 +1,86 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// CallbackArgs is the number of arguments every Callback receives.
+const CallbackArgs = 6
+
+// maxCallbacks is the number of Callbacks that can exist at once; each needs its own C function, and those have to be written out ahead of time.
+const maxCallbacks = 64
+
+// ErrTooManyCallbacks is returned by NewCallback when every Callback is in use.
+var ErrTooManyCallbacks = fmt.Errorf("dl: too many Callbacks; at most %d can exist at once", maxCallbacks)
+
+// errCallbackFreed is the panic of a Callback called after Free.
+var errCallbackFreed = errors.New("dl: Callback called after Free")
+
+// Callback is a Go function that C code can call through a function pointer, such as a log handler or allocator passed to a loaded library.
+// The C function takes CallbackArgs integer or pointer arguments, all of which are given to the Go function, and returns an integer or pointer; it can be given to C code expecting a function that takes fewer such arguments, and the extra ones will be garbage.
+// Floating-point arguments and return values, and structures passed by value, are not supported.
+// The Go function may be called on any thread, including ones the library started itself.
+type Callback struct {
+	slot	int
+	ptr	unsafe.Pointer
+}
+
+var callbacks struct {
+	lock	sync.RWMutex
+	funcs	[maxCallbacks]func(args []uintptr) uintptr
+}
+
+// NewCallback returns a Callback that calls f.
+// Call Free once the library can no longer call it, so its slot can be reused.
+func NewCallback(f func(args []uintptr) uintptr) (*Callback, error) {
+	callbacks.lock.Lock()
+	defer callbacks.lock.Unlock()
+
+	for i := range callbacks.funcs {
+		if callbacks.funcs[i] == nil {
+			p := trampoline(i)
+			if p == nil {
+				return nil, ErrUnsupported
+			}
+			callbacks.funcs[i] = f
+			return &Callback{
+				slot:	i,
+				ptr:	p,
+			}, nil
+		}
+	}
+	return nil, ErrTooManyCallbacks
+}
+
+// Pointer returns the C function pointer to give to the library.
+func (c *Callback) Pointer() unsafe.Pointer {
+	return c.ptr
+}
+
+// Free releases c; calling its pointer afterward panics until a later NewCallback reuses it, after which it calls that Callback's function instead.
+// Calling Free more than once does nothing.
+func (c *Callback) Free() {
+	callbacks.lock.Lock()
+	defer callbacks.lock.Unlock()
+	if c.ptr == nil {
+		return
+	}
+	callbacks.funcs[c.slot] = nil
+	c.ptr = nil
+}
+
+// callCallback runs the function of the Callback in the given slot; the trampolines call it.
+func callCallback(slot int, args []uintptr) uintptr {
+	callbacks.lock.RLock()
+	f := callbacks.funcs[slot]
+	callbacks.lock.RUnlock()
+	if f == nil {
+		panic(errCallbackFreed)
+	}
+	return f(args)
+}
@@ -0,0 +1,58 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+const callbackSource = `
+#include <stdint.h>
+uintptr_t apply(uintptr_t (*f)(uintptr_t, uintptr_t), uintptr_t a, uintptr_t b)
+{
+	return f(a, b);
+}
+`
+
+func TestCallbackArgsOutliveCall(t *testing.T) {
+	lib := dltest.Build(t, callbackSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	defer m.Close()
+	p, err := m.StrictSymbol("apply")
+	if err != nil {
+		t.Fatalf("StrictSymbol(apply): %v", err)
+	}
+	apply, err := dl.NewFunc(p, dl.CUint64, dl.CPointer, dl.CUint64, dl.CUint64)
+	if err != nil {
+		t.Skipf("NewFunc: %v", err)
+	}
+
+	var kept [][]uintptr
+	cb, err := dl.NewCallback(func(args []uintptr) uintptr {
+		kept = append(kept, args)
+		return args[0] + args[1]
+	})
+	if err != nil {
+		t.Skipf("NewCallback: %v", err)
+	}
+	defer cb.Free()
+
+	for _, c := range [][2]uint64{{1, 2}, {10, 20}} {
+		r, err := apply.Call(cb.Pointer(), c[0], c[1])
+		if err != nil || r != c[0] + c[1] {
+			t.Fatalf("apply(%d, %d) = %v, %v; want %d", c[0], c[1], r, err, c[0] + c[1])
+		}
+	}
+	// the slices must still hold what each call was given, not whatever is on the C stack now
+	if kept[0][0] != 1 || kept[0][1] != 2 || kept[1][0] != 10 || kept[1][1] != 20 {
+		t.Errorf("kept arguments = %v, %v; want [1 2 ...] and [10 20 ...]", kept[0][:2], kept[1][:2])
+	}
+}
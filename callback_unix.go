@@ -0,0 +1,22 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+// This file can only declare C things, because it has an //export; the trampolines themselves are in trampolines_unix.go.
+
+// #include <stdint.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+//export dlCallback
+func dlCallback(slot C.int, args *C.uintptr_t) C.uintptr_t {
+	// args is on the trampoline's C stack, which is gone once this returns, and the Go function is free to keep the slice it gets, so give it a copy
+	a := make([]uintptr, CallbackArgs)
+	copy(a, unsafe.Slice((*uintptr)(unsafe.Pointer(args)), CallbackArgs))
+	return C.uintptr_t(callCallback(int(slot), a))
+}
@@ -0,0 +1,26 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Windows can't free the function pointers syscall makes, so each slot's is made once and kept.
+var tramps struct {
+	lock	sync.Mutex
+	ptrs	[maxCallbacks]uintptr
+}
+
+func trampoline(slot int) unsafe.Pointer {
+	tramps.lock.Lock()
+	defer tramps.lock.Unlock()
+	if tramps.ptrs[slot] == 0 {
+		tramps.ptrs[slot] = syscall.NewCallbackCDecl(func(a0, a1, a2, a3, a4, a5 uintptr) uintptr {
+			return callCallback(slot, []uintptr{a0, a1, a2, a3, a4, a5})
+		})
+	}
+	return unsafe.Pointer(tramps.ptrs[slot])
+}
@@ -0,0 +1,38 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+)
+
+// OpenCandidates opens the first of names that can be opened, trying each in order, and returns its Module and the name that worked.
+// This is for libraries whose file names differ between systems or versions:
+// 	m, name, err := dl.OpenCandidates([]string{"libssl.so.3", "libssl.so.1.1", "libssl.so"}, dl.Lazy)
+// If none can be opened, the error lists why each one failed; errors.Is and errors.As see all of them.
+func OpenCandidates(names []string, mode Mode) (Module, string, error) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	return openCandidates(names, mode)
+}
+
+// openCandidates does the work of OpenCandidates.
+// The caller must hold dllock.
+func openCandidates(names []string, mode Mode) (Module, string, error) {
+	var errs []error
+
+	if len(names) == 0 {
+		return 0, "", errors.New("dl: no library names to try")
+	}
+	for _, name := range names {
+		m, err := open(name, mode)
+		if err == nil {
+			return m, name, nil
+		}
+		if errors.Is(err, ErrUnsupported) || errors.Is(err, ErrInvalidMode) {
+			return 0, "", err		// every other name would fail the same way
+		}
+		errs = append(errs, err)
+	}
+	return 0, "", errors.Join(errs...)
+}
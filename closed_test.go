@@ -0,0 +1,34 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestClosedModule(t *testing.T) {
+	lib := dltest.Build(t, "int answer(void) { return 42; }\n")
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := m.SymbolVersion("answer", "V1"); !errors.Is(err, dl.ErrClosed) && !errors.Is(err, dl.ErrUnsupported) {
+		t.Errorf("SymbolVersion after Close error = %v; want ErrClosed", err)
+	}
+	if _, err := m.Path(); !errors.Is(err, dl.ErrClosed) {
+		t.Errorf("Path after Close error = %v; want ErrClosed", err)
+	}
+	if _, err := m.CheckBindings(); !errors.Is(err, dl.ErrClosed) {
+		t.Errorf("CheckBindings after Close error = %v; want ErrClosed", err)
+	}
+}
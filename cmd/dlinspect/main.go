@@ -0,0 +1,153 @@
+// 14 october 2026
+
+/*
+Dlinspect reports what the dynamic linker makes of a library: where it was found, what it depends on, what it needs that nothing provides, what it exports, and whether the symbols you need are there.
+It is meant for working out why a plugin won't load on a particular machine.
+
+Usage:
+
+	dlinspect [flags] library [symbol ...]
+
+The library is opened with package dl exactly as a program would open it, so a bare name is searched for the usual way.
+Each symbol given is looked up, and dlinspect exits with status 1 if any are missing or the library can't be opened.
+
+The flags are:
+
+	-mode string
+		the mode to open the library with, as accepted by dl.ParseMode (default "Lazy|Local")
+	-exports
+		also list every symbol the library exports
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/andlabs/dl"
+)
+
+var (
+	modeFlag	= flag.String("mode", "Lazy|Local", "the `mode` to open the library with")
+	exportsFlag	= flag.Bool("exports", false, "list every symbol the library exports")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s [flags] library [symbol ...]\n", os.Args[0])
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 {
+		usage()
+	}
+	name := flag.Arg(0)
+	mode, err := dl.ParseMode(*modeFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dlinspect: %v\n", err)
+		os.Exit(2)
+	}
+
+	fmt.Printf("library:\t%s\n", name)
+	m, d, err := dl.OpenDiagnostic(name, mode)
+	if d.LibraryPath != "" {
+		fmt.Printf("LD_LIBRARY_PATH:\t%s\n", d.LibraryPath)
+	}
+	if err != nil {
+		fmt.Printf("open failed:\t%v\n", err)
+		explain(name)
+		os.Exit(1)
+	}
+	defer m.Close()
+
+	if path, err := m.Path(); err == nil {
+		fmt.Printf("path:\t%s\n", path)
+	} else if d.Path != "" {
+		fmt.Printf("path:\t%s\n", d.Path)
+	}
+	for _, p := range d.Shadowed {
+		fmt.Printf("shadowed:\t%s\n", p)
+	}
+	if soname, err := m.SOName(); err == nil && soname != "" {
+		fmt.Printf("soname:\t%s\n", soname)
+	}
+	if id, err := m.BuildID(); err == nil && id != "" {
+		fmt.Printf("build ID:\t%s\n", id)
+	}
+	deps(m)
+	if unbound, err := m.CheckBindings(); err == nil {
+		for _, sym := range unbound {
+			fmt.Printf("unbound:\t%s\n", sym)
+		}
+	}
+	if *exportsFlag {
+		exports(m)
+	}
+
+	missing := 0
+	for _, sym := range flag.Args()[1:] {
+		p, err := m.Symbol(sym)
+		if err != nil {
+			fmt.Printf("missing:\t%s\n", sym)
+			missing++
+			continue
+		}
+		fmt.Printf("found:\t%s at %p\n", sym, p)
+	}
+	if missing != 0 {
+		m.Close()
+		os.Exit(1)
+	}
+}
+
+// explain prints what can be found out about why name would not open.
+func explain(name string) {
+	path, err := dl.Resolve(name)
+	if err != nil {
+		fmt.Printf("resolve:\t%v\n", err)
+		return
+	}
+	fmt.Printf("resolves to:\t%s\n", path)
+	if err := dl.ValidateFile(path); err != nil {
+		fmt.Printf("invalid:\t%v\n", err)
+	}
+}
+
+// deps prints the libraries m needs, and where each was found.
+func deps(m dl.Module) {
+	needed, err := m.Dependencies()
+	if err != nil {
+		fmt.Printf("dependencies:\t%v\n", err)
+		return
+	}
+	for _, n := range needed {
+		fmt.Printf("needs:\t%s\n", n)
+	}
+	loaded, err := m.LoadedDependencies()
+	if err != nil {
+		return
+	}
+	for _, p := range loaded {
+		fmt.Printf("loaded:\t%s\n", p)
+	}
+}
+
+// exports prints the symbols m exports.
+func exports(m dl.Module) {
+	syms, err := m.ExportedSymbols()
+	if err != nil {
+		fmt.Printf("exports:\t%v\n", err)
+		return
+	}
+	for _, s := range syms {
+		name := s.Name
+		if s.Version != "" {
+			name += "@" + s.Version
+		}
+		fmt.Printf("exports:\t%s\t%v\t%d\n", name, s.Type, s.Size)
+	}
+}
@@ -0,0 +1,70 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// SymbolCxx looks up a C++ symbol by its demangled name, such as "Foo::bar(int)" or "ns::make_widget()", instead of its mangled name (here, "_ZN3Foo3barEi" and "_ZN2ns11make_widgetEv").
+// The name must be written the way the demangler writes it, except that spaces are ignored.
+// If the parameter list is left out, the name matches every overload; it is an error if there is more than one.
+//
+// SymbolCxx demangles every symbol m exports, so it needs ExportedSymbols, and it uses the Itanium ABI's demangler, __cxa_demangle(), from whichever C++ runtime is already loaded, or from libstdc++ or libc++abi otherwise.
+// On systems where either isn't available, it returns ErrUnsupported.
+func (m Module) SymbolCxx(demangled string) (unsafe.Pointer, error) {
+	syms, err := m.ExportedSymbols()
+	if err != nil {
+		return nil, err
+	}
+	demangle, err := cxxDemangler()
+	if err != nil {
+		return nil, err
+	}
+
+	want := cxxNormalize(demangled)
+	withParams := strings.Contains(want, "(")
+	var found []string
+	for _, s := range syms {
+		if !strings.HasPrefix(s.Name, "_Z") {
+			continue
+		}
+		d, ok := demangle(s.Name)
+		if !ok {
+			continue
+		}
+		d = cxxNormalize(d)
+		if !withParams {
+			if i := strings.Index(d, "("); i != -1 {
+				d = d[:i]
+			}
+		}
+		if d == want {
+			found = append(found, s.Name)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		dllock.RLock()
+		lib := m.libraryName()
+		dllock.RUnlock()
+		return nil, &Error{
+			Op:		"symbol",
+			Library:	lib,
+			Symbol:	demangled,
+			Err:		ErrSymbolNotFound,
+			Msg:		fmt.Sprintf("dl: no symbol demangles to %s", demangled),
+		}
+	case 1:
+		return m.Symbol(found[0])
+	}
+	return nil, fmt.Errorf("dl: %s is overloaded (%s); give the parameter list", demangled, strings.Join(found, ", "))
+}
+
+// cxxNormalize removes the spaces from a demangled name, so names written by hand match what the demangler writes.
+func cxxNormalize(name string) string {
+	return strings.Join(strings.Fields(name), "")
+}
@@ -0,0 +1,60 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// static char *calldemangle(void *f, const char *name)
+// {
+// 	char *(*demangle)(const char *, char *, size_t *, int *);
+// 	int status;
+//
+// 	*((void **) (&demangle)) = f;
+// 	return (*demangle)(name, NULL, NULL, &status);
+// }
+import "C"
+
+var (
+	demangleOnce	sync.Once
+	demangleFunc	unsafe.Pointer
+	demangleErr	error
+)
+
+// cxxDemangler finds __cxa_demangle() and returns a function that calls it.
+// Any C++ library will have loaded the C++ runtime already; if nothing has, the C++ runtime is opened and never closed.
+func cxxDemangler() (func(string) (string, bool), error) {
+	demangleOnce.Do(func() {
+		demangleFunc, demangleErr = Default().Symbol("__cxa_demangle")
+		if demangleErr == nil && demangleFunc != nil {
+			return
+		}
+		rt, _, err := OpenCandidates([]string{"libstdc++.so.6", "libc++abi.so.1", "libc++abi.dylib"}, Lazy)
+		if err != nil {
+			demangleErr = ErrUnsupported
+			return
+		}
+		demangleFunc, demangleErr = rt.Symbol("__cxa_demangle")
+		if demangleErr != nil || demangleFunc == nil {
+			demangleErr = ErrUnsupported
+		}
+	})
+	if demangleErr != nil {
+		return nil, demangleErr
+	}
+	return func(name string) (string, bool) {
+		cname := C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+		d := C.calldemangle(demangleFunc, cname)
+		if d == nil {
+			return "", false
+		}
+		defer C.free(unsafe.Pointer(d))
+		return C.GoString(d), true
+	}, nil
+}
@@ -0,0 +1,8 @@
+// 14 october 2026
+
+package dl
+
+// Windows C++ compilers don't use the Itanium ABI, and ExportedSymbols isn't available anyway.
+func cxxDemangler() (func(string) (string, bool), error) {
+	return nil, ErrUnsupported
+}
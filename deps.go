@@ -0,0 +1,94 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"path/filepath"
+)
+
+// Dependencies returns the names of the libraries m was linked against (its DT_NEEDED entries), in the order the dynamic linker loads them.
+// These are sonames such as "libc.so.6", not paths; see LoadedDependencies for where they were found.
+// This only works on systems that use ELF, and returns ErrUnsupported elsewhere.
+func (m Module) Dependencies() ([]string, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.Dependencies)
+	}
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return needed(o.path)
+}
+
+func needed(path string) ([]string, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.ImportedLibraries()
+}
+
+// LoadedDependencies returns the paths of every loaded object m depends on, directly or indirectly, in breadth-first order.
+// Each dependency is matched to a loaded object by its soname, or failing that by its file name.
+// Dependencies that are somehow not loaded (for instance, because they were unloaded by other code after m was loaded) are left out.
+func (m Module) LoadedDependencies() ([]string, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.LoadedDependencies)
+	}
+	dllock.Lock()
+	o, err := m.object()
+	var objs []LoadedObject
+	if err == nil {
+		objs, err = loadedObjects()
+	}
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// work out what name each loaded object satisfies
+	byName := make(map[string]string)
+	for _, obj := range objs {
+		if obj.Name == "" {
+			continue
+		}
+		if _, ok := byName[filepath.Base(obj.Name)]; !ok {
+			byName[filepath.Base(obj.Name)] = obj.Name
+		}
+		f, err := elf.Open(obj.Name)
+		if err != nil {
+			continue
+		}
+		if sonames, err := f.DynString(elf.DT_SONAME); err == nil && len(sonames) != 0 {
+			byName[sonames[0]] = obj.Name
+		}
+		f.Close()
+	}
+
+	var deps []string
+	seen := map[string]bool{
+		o.path:	true,
+	}
+	queue := []string{o.path}
+	for len(queue) != 0 {
+		names, err := needed(queue[0])
+		queue = queue[1:]
+		if err != nil {
+			continue
+		}
+		for _, n := range names {
+			p, ok := byName[n]
+			if !ok || seen[p] {
+				continue
+			}
+			seen[p] = true
+			deps = append(deps, p)
+			queue = append(queue, p)
+		}
+	}
+	return deps, nil
+}
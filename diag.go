@@ -0,0 +1,58 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"os"
+	"strings"
+)
+
+// LoadDiagnostics describes how the dynamic linker went about satisfying an Open.
+type LoadDiagnostics struct {
+	// LibraryPath is the value of LD_LIBRARY_PATH as this process sees it.
+	// This is not necessarily the value your shell has.
+	LibraryPath	string
+
+	// Path is the file that was actually loaded, or an empty string if it could not be determined.
+	Path		string
+
+	// Shadowed lists other files that Resolve would have considered, in search order.
+	// These are the files that you might have expected to load instead of Path.
+	Shadowed		[]string
+}
+
+// OpenDiagnostic is like Open, but also reports where the library was found and what other files of the same name were passed over.
+// The LoadDiagnostics is returned even if Open fails.
+// (A name containing a slash is not searched for, so Shadowed will always be empty for one.)
+func OpenDiagnostic(name string, mode Mode) (Module, *LoadDiagnostics, error) {
+	d := &LoadDiagnostics{
+		LibraryPath:	os.Getenv("LD_LIBRARY_PATH"),
+	}
+	m, err := Open(name, mode)
+	if err == nil {
+		dllock.Lock()
+		if o, err := m.object(); err == nil {
+			d.Path = o.path
+		}
+		dllock.Unlock()
+	}
+	if strings.Contains(name, "/") {
+		return m, d, err
+	}
+
+	var loaded os.FileInfo
+	if d.Path != "" {
+		loaded, _ = os.Stat(d.Path)
+	}
+	for _, p := range candidates(name) {
+		fi, serr := os.Stat(p)
+		if serr != nil || fi.IsDir() {
+			continue
+		}
+		if loaded != nil && os.SameFile(fi, loaded) {
+			continue
+		}
+		d.Shadowed = append(d.Shadowed, p)
+	}
+	return m, d, err
+}
@@ -0,0 +1,42 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestOpenDiagnosticShadowed(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	first, second := t.TempDir(), t.TempDir()
+	copyFile(t, lib, filepath.Join(first, "libdiagtest.so"))
+	copyFile(t, lib, filepath.Join(second, "libdiagtest.so"))
+
+	// the dynamic linker only reads LD_LIBRARY_PATH at startup, so have Open search the way Resolve does
+	t.Setenv("LD_LIBRARY_PATH", first + string(os.PathListSeparator) + second)
+	dl.SetResolver(dl.Resolve)
+	defer dl.SetResolver(nil)
+
+	m, d, err := dl.OpenDiagnostic("libdiagtest.so", dl.Now)
+	if err != nil {
+		t.Fatalf("OpenDiagnostic: %v", err)
+	}
+	defer m.Close()
+	if want := filepath.Join(first, "libdiagtest.so"); d.Path != want {
+		t.Errorf("Path = %q; want %q", d.Path, want)
+	}
+	if d.LibraryPath != os.Getenv("LD_LIBRARY_PATH") {
+		t.Errorf("LibraryPath = %q; want %q", d.LibraryPath, os.Getenv("LD_LIBRARY_PATH"))
+	}
+	want := filepath.Join(second, "libdiagtest.so")
+	if len(d.Shadowed) == 0 || d.Shadowed[0] != want {
+		t.Errorf("Shadowed = %q; want it to start with %q", d.Shadowed, want)
+	}
+}
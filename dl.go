@@ -1,15 +1,19 @@
 // 5 july 2014
 
 /*
-Package dl implements access to libdl, the library for loading dynamic modules on Unix systems.
+Package dl implements access to libdl, the library for loading dynamic modules on Unix systems, and to its Windows equivalent, LoadLibrary().
 
 It is not intended to provide a way to create dynamic modules in Go itself; it is merely provided to allow loading of pre-existing native modules, such as plugins for multimedia libraries, at runtime.
 
 It is intended to be safe for concurrent use. (This is also why the package exists.)
 
-Only features defined in the Single Unix Specification are supported.
+Open, OpenSelf, Close, and Symbol use only features defined in the Single Unix Specification (or LoadLibraryExW(), FreeLibrary(), and GetProcAddress() on Windows); everything else documents the systems it works on. On systems that cannot load native libraries at all, such as js/wasm, wasip1, and Plan 9, the package still builds, but Open and OpenSelf return ErrUnsupported.
 
-This package cannot be used by itself, as the function pointers it returns are incompatible with Go. You will still need cgo.
+This package cannot be used by itself, as the function pointers it returns are incompatible with Go. You will still need cgo, unless the functions you need are simple enough to call through a Func (see NewFunc).
+
+Outside Windows, the package itself calls the dynamic linker through cgo, so building it needs cgo enabled and a C compiler; with CGO_ENABLED=0 it does not build on Unix systems. There is no cgo-free backend.
+
+The package needs Go 1.24 or newer: OpenAt takes an *os.Root, which Go 1.24 introduced, and the C functions symbol lookups call are marked with cgo's noescape and nocallback directives, which older versions reject. (ELF symbol types such as STT_GNU_IFUNC need Go 1.23 already.)
 
 Here is an example:
 
@@ -45,93 +49,285 @@ import (
 	"sync"
 	"unsafe"
 	"errors"
+	"time"
 )
 
-// #cgo LDFLAGS: -ldl
-// #include <dlfcn.h>
-// #include <stdlib.h>
-import "C"
+// dllock guards the dynamic linker and the package's books.
+// Symbol lookups only need it for reading (see symlock()); everything else takes it for writing.
+var dllock sync.RWMutex
 
-var dllock sync.Mutex
+// symlock locks dllock for a symbol lookup.
+// Where dlerror() is per-thread, lookups only exclude opening and closing, not each other; elsewhere, nothing changes.
+// The unlock functions capture nothing, so (unlike the method values dllock.RUnlock and dllock.Unlock) returning them doesn't allocate.
+func symlock() (unlock func()) {
+	if threadLocalDlerror {
+		dllock.RLock()
+		return func() {
+			dllock.RUnlock()
+		}
+	}
+	dllock.Lock()
+	return func() {
+		dllock.Unlock()
+	}
+}
 
 // Module represents a handle to an open library.
 type Module uintptr
 
-func dlerror() error {
-	return errors.New(C.GoString(C.dlerror()))
-}
-
 // Mode represents a mode passed to Open().
+// The available modes depend on the system.
 type Mode uintptr
-const (
-	Now Mode = C.RTLD_NOW
-	Lazy Mode = C.RTLD_LAZY
-	Global Mode = C.RTLD_GLOBAL
-	Local Mode = C.RTLD_LOCAL
-)
 
-// Note: the SUS does define RTLD_DEFAULT and RTLD_NOW as reserved for future use; while they do work in glibc, you need _GNU_SOURCE defined, so I won't include them.
+// Supported returns whether the system can honor every mode in m.
+// Modes your system doesn't have cause Open and OpenSelf to return ErrUnsupported; modes that contradict each other, such as Now|Lazy, cause them to return an error wrapping ErrInvalidMode.
+func (m Mode) Supported() bool {
+	return m & unsupportedModes == 0
+}
+
+// The rest of this file is the same on every system; each system provides the following, all of which must be called with dllock held:
+// 	func sysopen(name string, mode Mode) (Module, error)
+// 	func sysopenself(mode Mode) (Module, error)
+// 	func (m Module) sysclose() error
+// 	func (m Module) syssymbol(name string) (unsafe.Pointer, error)
+// 	func (m Module) sysprobe(name string) (unsafe.Pointer, bool)
 
 // Open opens the named library, obeying the system's rule for absolute and relative library lookup.
 // If the load fails, 0 is returned.
 func Open(name string, mode Mode) (Module, error) {
 	dllock.Lock()
 	defer dllock.Unlock()
+	return open(name, mode)
+}
+
+// open does the work of Open.
+// The caller must hold dllock.
+func open(name string, mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	path, err := loadPath(name)
+	if err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	if err := checkPolicy(name, path); err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	if validate {
+		if err := validateOpen(path); err != nil {
+			return finishOpen(name, mode, start, 0, err)
+		}
+	}
+	m, err := sysopen(path, mode)
+	if err != nil {
+		err = openError(name, path, mode, err)
+	}
+	return finishOpen(name, mode, start, m, err)
+}
+
+// loadPath returns the file to load for name: what the Resolver says, if there is one; otherwise what Resolve finds, if there is a Policy to check it; and otherwise name itself, for the dynamic linker to search for.
+// The caller must hold dllock.
+func loadPath(name string) (string, error) {
+	if resolver != nil {
+		return resolver(name)
+	}
+	if policy != nil {
+		if p, err := Resolve(name); err == nil {
+			return p, nil		// so the policy checks exactly what gets loaded
+		}
+	}
+	return name, nil
+}
 
-	C.dlerror()		// clear previous error state
-	cname := C.CString(name)
-	defer C.free(unsafe.Pointer(cname))
-	m := C.dlopen(cname, C.int(mode))
-	if m == nil {
-		return 0, dlerror()
+// finishOpen does the package's bookkeeping for an open of name with mode that began at start and returned m and err.
+// Anything that opens libraries other than through open should give its results to finishOpen.
+// OpenSelf gives an empty name, which SetForbidDuplicateOpen doesn't apply to.
+// The caller must hold dllock.
+func finishOpen(name string, mode Mode, start time.Time, m Module, err error) (Module, error) {
+	if err == nil {
+		err = opened(m, name, mode, name != "")
+		if err != nil {
+			m.sysclose()		// drop the reference we just took
+		} else {
+			share(m)
+		}
 	}
-	return Module(m), nil
+	if err != nil {
+		countOpen(start, false)
+		trace("open", name, "", 0, start, err)
+		return 0, err
+	}
+	countOpen(start, true)
+	trace("open", name, "", m, start, nil)
+	return m, nil
 }
 
 // OpenSelf opens the current process.
 // This is equivalent to calling dlopen() with a NULL filename.
+// SelfSymbols lists what the program itself exports.
 // If the load fails, 0 is returned.
 func OpenSelf(mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+
 	dllock.Lock()
 	defer dllock.Unlock()
 
-	C.dlerror()		// clear previous error state
-	m := C.dlopen(nil, C.int(mode))
-	if m == nil {
-		return 0, dlerror()
+	start := time.Now()
+	m, err := sysopenself(mode)
+	if err != nil {
+		err = &Error{
+			Op:		"open",
+			Err:		classify(err),
+			Msg:		err.Error(),
+			Mode:	mode,
+		}
 	}
-	return Module(m), nil
+	return finishOpen("", mode, start, m, err)
 }
 
 // Close closes the Module.
 // Symbols loaded from the Module should not be used after Close is called, even if there are other outstanding referneces to the dynamic library keeping it in memory.
-func (m Module) Close() error {
+// Once every open of a Module through this package is closed, using or closing it again returns an error wrapping ErrClosed.
+func (m Module) Close() (err error) {
+	if t := m.boundThread(); t != nil {
+		t.Do(func() {
+			err = m.Close()
+		})
+		return err
+	}
+
 	dllock.Lock()
 	defer dllock.Unlock()
+	return m.close()
+}
 
-	C.dlerror()		// clear previous error state
-	if C.dlclose(unsafe.Pointer(m)) != 0 {
-		return dlerror()
+// close does the work of Close.
+// The caller must hold dllock.
+func (m Module) close() error {
+	if m.pseudo() {
+		return nil
+	}
+	if err := m.closedError("close", ""); err != nil {
+		return err
 	}
+	start := time.Now()
+	name := m.libraryName()
+	mi := modules[m]
+	if mi == nil || mi.sysrefs >= mi.refs {		// otherwise this reference was shared; see SetShareOpens()
+		if err := m.unload(); err != nil {
+			err = m.closeError(err)
+			trace("close", name, "", m, start, err)
+			return err
+		}
+		if mi != nil {
+			mi.sysrefs--
+		}
+	}
+	ncloses.Add(1)
+	closed(m)
+	trace("close", name, "", m, start, nil)
 	return nil
 }
 
 // Symbol looks up the given named symbol in the Module.
-// Note that the value of Symbol can be nil, so checking symbol for nil will not indicate an error; checking err for nil is.
+// Note that the value of Symbol can be nil, so checking symbol for nil will not indicate an error; checking err for nil is. (StrictSymbol treats a nil value as an error instead.)
+// (On Windows, a symbol whose value is nil cannot be told apart from one that does not exist, so it is reported as an error.)
+// Symbols found in a Module opened by this package are cached, so looking up the same symbol again is cheap, and does not allocate.
+// Lookups can happen at the same time as each other, but not at the same time as opening or closing a library.
+// On OS X, the name can be given with or without the underscore Mach-O puts in front of C names.
 func (m Module) Symbol(name string) (symbol unsafe.Pointer, err error) {
-	dllock.Lock()
-	defer dllock.Unlock()
+	if t := m.boundThread(); t != nil {
+		return m.symbolOn(t, name)
+	}
+	defer symlock()()
+	return m.symbol(name)
+}
 
-	C.dlerror()		// clear previous error state
-	cname := C.CString(name)
-	defer C.free(unsafe.Pointer(cname))
-	symbol = C.dlsym(unsafe.Pointer(m), cname)
-	if symbol == nil {
-		e := C.dlerror()
-		if e == nil {		// no error; symbol value is NULL
-			return nil, nil
+// symbol does the work of Symbol.
+// The caller must hold dllock, for reading at least.
+func (m Module) symbol(name string) (symbol unsafe.Pointer, err error) {
+	start := time.Now()
+	mi := modules[m]
+	if mi == nil {
+		if err := m.closedError("symbol", name); err != nil {
+			return nil, err
+		}
+	}
+	if mi != nil {
+		if s, ok := mi.cached(name); ok {
+			trace("symbol", mi.name, name, m, start, nil)
+			return s, nil
 		}
-		return nil, errors.New(C.GoString(e))
 	}
+	if mi != nil && mi.rules != nil {
+		symbol, err = m.ruleSymbol(name, mi.rules)
+	} else {
+		symbol, err = m.syssymbol(name)
+	}
+	if err != nil {
+		err = m.symbolError(name, err)
+		trace("symbol", m.libraryName(), name, m, start, err)
+		return nil, err
+	}
+	if mi != nil {
+		mi.cache(name, symbol)
+	}
+	trace("symbol", m.libraryName(), name, m, start, nil)
 	return symbol, nil
 }
+
+// Symbols looks up each of the given named symbols in the Module, all at once.
+// The returned map holds every symbol found; the returned error lists every symbol that was not.
+// This is the easiest way to find out everything a library is missing, rather than just the first thing.
+func (m Module) Symbols(names ...string) (syms map[string]unsafe.Pointer, err error) {
+	var errs []error
+
+	if t := m.boundThread(); t != nil {
+		t.Do(func() {
+			syms, err = m.Symbols(names...)
+		})
+		return syms, err
+	}
+	defer symlock()()
+
+	syms = make(map[string]unsafe.Pointer, len(names))
+	for _, name := range names {
+		s, err := m.symbol(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		syms[name] = s
+	}
+	return syms, errors.Join(errs...)
+}
+
+// SymbolAny looks up each of the given named symbols in the Module in turn, and returns the first one found and its name.
+// This is for symbols that were renamed between versions of a library; list the names newest first.
+// If none are found, the returned error lists every failed lookup.
+func (m Module) SymbolAny(names ...string) (symbol unsafe.Pointer, found string, err error) {
+	var errs []error
+
+	if t := m.boundThread(); t != nil {
+		t.Do(func() {
+			symbol, found, err = m.SymbolAny(names...)
+		})
+		return symbol, found, err
+	}
+	defer symlock()()
+
+	for _, name := range names {
+		s, err := m.symbol(name)
+		if err == nil {
+			return s, name, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, "", errors.New("dl: no symbol names to try")
+	}
+	return nil, "", errors.Join(errs...)
+}
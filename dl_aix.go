@@ -0,0 +1,40 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"strings"
+)
+
+// #include <dlfcn.h>
+import "C"
+
+// Member lets Open load a shared object that is a member of an archive, which is how AIX normally ships shared libraries.
+// The name is then given as "libfoo.a(shr_64.o)"; Open adds Member by itself when it sees a name like that.
+const Member Mode = C.RTLD_MEMBER
+
+func init() {
+	modeNames = append(modeNames, modeName{ Member, "Member" })
+}
+
+// isMember returns whether name is of the form archive(member).
+func isMember(name string) bool {
+	i := strings.LastIndex(name, "(")
+	return i > 0 && strings.HasSuffix(name, ")") && i < len(name) - 2
+}
+
+// openMode returns the mode to actually pass to dlopen() for name: mode, with Member added if name is an archive member that mode doesn't already ask for.
+func openMode(name string, mode Mode) Mode {
+	if isMember(name) {
+		mode |= Member
+	}
+	return mode
+}
+
+func altName(name string) string {
+	return ""
+}
+
+func altSymbol(name string) string {
+	return ""
+}
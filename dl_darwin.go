@@ -0,0 +1,85 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"unsafe"
+)
+
+// dlopen() and friends live in libSystem on OS X, so there is no -ldl; see dl_unix.go.
+
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// static int preflight(const char *path, char **err)
+// {
+// 	*err = NULL;
+// 	dlerror();
+// 	if (dlopen_preflight(path))
+// 		return 1;
+// 	*err = dlerrordup();
+// 	return 0;
+// }
+import "C"
+
+// First is a dyld extension: symbol lookups in a Module opened with First only search that image, not the images it depends on.
+// It applies to Symbol only; Open still loads the dependencies as usual.
+const First Mode = C.RTLD_FIRST
+
+func init() {
+	modeNames = append(modeNames, modeName{ First, "First" })
+}
+
+// dylibName translates ELF-style library names to the dyld convention: "libfoo.so" becomes "libfoo.dylib" and "libfoo.so.3" becomes "libfoo.3.dylib".
+// It returns an empty string if name does not look like an ELF library name.
+// Open tries the translated name if the name given doesn't load, so portable programs can use ELF names everywhere.
+func dylibName(name string) string {
+	dir, file := path.Split(name)
+	i := strings.Index(file, ".so")
+	if i == -1 || (i + 3 != len(file) && file[i + 3] != '.') {
+		return ""
+	}
+	base, version := file[:i], file[i + 3:]		// version is either empty or starts with a dot
+	return dir + base + version + ".dylib"
+}
+
+func altName(name string) string {
+	return dylibName(name)
+}
+
+// altSymbol deals with the underscore Mach-O puts in front of every C symbol name.
+// dlsym() adds it itself, so "_foo" (as nm and other tools show the name) is really looked up as "__foo"; if that isn't found, try "foo" instead.
+// Symbols written in assembly or with an asm label might not have the extra underscore, so if "foo" isn't found, try "_foo", which finds the Mach-O symbol "__foo".
+func altSymbol(name string) string {
+	if strings.HasPrefix(name, "_") {
+		return name[1:]
+	}
+	return "_" + name
+}
+
+// openMode returns the mode to actually pass to dlopen() for name, which on OS X is always mode itself.
+func openMode(name string, mode Mode) Mode {
+	return mode
+}
+
+// Preflight reports whether the Mach-O file at path could be loaded by Open, without loading it.
+// This wraps dlopen_preflight(); it checks that the file is a compatible Mach-O image and that its dependencies can be found, but does not run any of its code.
+func Preflight(path string) error {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	var e *C.char
+
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	if C.preflight(cpath, &e) == 0 {
+		if e != nil {
+			return takeError(e)
+		}
+		return errors.New("dl: " + path + " cannot be loaded")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// 14 october 2026
+
+//go:build !unix && !windows
+
+package dl
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// This system (such as js/wasm, wasip1, or Plan 9) can't load native libraries at all.
+// The package still builds so that programs which can do without plugins build everywhere, but every attempt to open a library returns ErrUnsupported.
+
+// These modes exist so portable code can name them; they have no effect.
+const (
+	Now Mode = 1 << iota
+	Lazy
+	Global
+	Local
+)
+
+// there is no dlerror() to share
+const threadLocalDlerror = true
+
+func sysopen(name string, mode Mode) (Module, error) {
+	return 0, ErrUnsupported
+}
+
+func sysopenself(mode Mode) (Module, error) {
+	return 0, ErrUnsupported
+}
+
+func (m Module) pseudo() bool {
+	return false
+}
+
+func (m Module) sysclose() error {
+	return ErrUnsupported
+}
+
+func (m Module) syssymbol(name string) (unsafe.Pointer, error) {
+	return nil, ErrUnsupported
+}
+
+func (m Module) sysprobe(name string) (unsafe.Pointer, bool) {
+	return nil, false
+}
+
+func (m Module) base() (uintptr, error) {
+	return 0, ErrUnsupported
+}
+
+// threads can't be told apart; see OSThread.onThread
+func threadID() uintptr {
+	return 0
+}
+
+func errnoOf(err error) error {
+	return nil
+}
+
+func classify(err error) error {
+	if errors.Is(err, ErrUnsupported) {
+		return ErrUnsupported
+	}
+	return nil
+}
+
+func (m Module) path() (string, error) {
+	return "", ErrUnsupported
+}
+
+// Addr returns ErrUnsupported on this system.
+func Addr(ptr unsafe.Pointer) (*AddrInfo, error) {
+	return nil, ErrUnsupported
+}
+
+// IsLoaded returns ErrUnsupported on this system.
+func IsLoaded(name string) (bool, error) {
+	return false, ErrUnsupported
+}
+
+// Promote returns ErrUnsupported on this system.
+func (m Module) Promote() error {
+	return ErrUnsupported
+}
+
+func cxxDemangler() (func(string) (string, bool), error) {
+	return nil, ErrUnsupported
+}
+
+// there are no Callbacks here; see NewCallback
+func trampoline(slot int) unsafe.Pointer {
+	return nil
+}
+
+// Func is a C function that can be called directly from Go; there are none on this system.
+type Func struct{}
+
+// NewFunc returns ErrUnsupported on this system.
+func NewFunc(fn unsafe.Pointer, ret CType, args ...CType) (*Func, error) {
+	return nil, ErrUnsupported
+}
+
+// Call returns ErrUnsupported on this system.
+func (f *Func) Call(args ...interface{}) (interface{}, error) {
+	return nil, ErrUnsupported
+}
+
+func sysdup(path string) (Module, error) {
+	return 0, ErrUnsupported
+}
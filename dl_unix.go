@@ -0,0 +1,294 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"unsafe"
+	"errors"
+	"runtime"
+	"strings"
+	"syscall"
+)
+
+// The noescape and nocallback directives below, which keep symbol lookups from allocating, need Go 1.24; older versions of cgo reject them.
+
+// #cgo !darwin LDFLAGS: -ldl
+// #cgo noescape dlsymerr
+// #cgo nocallback dlsymerr
+// #cgo noescape dlsymok
+// #cgo nocallback dlsymok
+// #include <dlfcn.h>
+// #include <errno.h>
+// #include <stdlib.h>
+// #include <string.h>
+// /* Go can move us to another thread between two cgo calls, so each call into the dynamic linker is wrapped in a C function that clears dlerror() before it and takes dlerror() after it, all on the same thread */
+// /* the message is copied because the buffer it lives in belongs to the C library, and the next call on that thread can overwrite it */
+// /* dlerrordup() is used by the wrappers in other files too, so it isn't static; they declare it extern */
+// char *dlerrordup(void)
+// {
+// 	const char *e;
+//
+// 	e = dlerror();
+// 	if (e == NULL)
+// 		return NULL;
+// 	return strdup(e);
+// }
+// void *dlopenerr(const char *name, int mode, char **err)
+// {
+// 	void *h;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	h = dlopen(name, mode);
+// 	if (h == NULL)
+// 		*err = dlerrordup();
+// 	return h;
+// }
+// /* dlopen() for Open also keeps errno, in case it's from whatever open() or mmap() failed inside; see withErrno() */
+// static void *dlopenerrno(const char *name, int mode, char **err, int *errnum)
+// {
+// 	void *h;
+//
+// 	*err = NULL;
+// 	*errnum = 0;
+// 	dlerror();
+// 	errno = 0;
+// 	h = dlopen(name, mode);
+// 	if (h == NULL) {
+// 		*errnum = errno;
+// 		*err = dlerrordup();
+// 	}
+// 	return h;
+// }
+// static int dlcloseerr(void *handle, char **err)
+// {
+// 	int r;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	r = dlclose(handle);
+// 	if (r != 0)
+// 		*err = dlerrordup();
+// 	return r;
+// }
+// static void *dlsymerr(void *handle, const char *name, char **err)
+// {
+// 	void *sym;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	sym = dlsym(handle, name);
+// 	if (sym == NULL)
+// 		*err = dlerrordup();		/* stays NULL if the symbol's value is NULL */
+// 	return sym;
+// }
+// /* for LookupOK, which only wants to know whether the symbol exists, so there's no message to copy */
+// static void *dlsymok(void *handle, const char *name, int *ok)
+// {
+// 	void *sym;
+//
+// 	dlerror();
+// 	sym = dlsym(handle, name);
+// 	*ok = sym != NULL || dlerror() == NULL;
+// 	return sym;
+// }
+import "C"
+
+// threadLocalDlerror is whether dlerror() keeps a separate error for each thread, which lets Symbol run without excluding other lookups.
+// This is the case in glibc, musl, and dyld; other systems are assumed to share one error between all threads.
+// On those, other cgo code calling into the dynamic linker at the same time can still overwrite an error before it is taken; dllock only keeps this package's own calls apart.
+const threadLocalDlerror = runtime.GOOS == "linux" || runtime.GOOS == "darwin"
+
+// takeError makes an error from a message from dlerrordup(), and frees the message.
+func takeError(e *C.char) error {
+	if e == nil {		// some failures don't set one; for instance, Noload of a library that isn't loaded
+		return errors.New("dl: failed without an error message")
+	}
+	defer C.free(unsafe.Pointer(e))
+	return errors.New(C.GoString(e))
+}
+
+const (
+	Now Mode = C.RTLD_NOW
+	Lazy Mode = C.RTLD_LAZY
+	Global Mode = C.RTLD_GLOBAL
+	Local Mode = C.RTLD_LOCAL
+)
+
+// Note: the SUS does define RTLD_DEFAULT and RTLD_NEXT as reserved for future use; they are available as pseudo-Modules with Default() and Next().
+
+func sysopen(name string, mode Mode) (Module, error) {
+	m, err := dlopen(name, mode)
+	if err != nil {
+		// give the system's naming convention a try before giving up (see dylibName())
+		if alt := altName(name); alt != "" {
+			if m, err2 := dlopen(alt, mode); err2 == nil {
+				return m, nil
+			}
+		}
+		return 0, err
+	}
+	return m, nil
+}
+
+func dlopen(name string, mode Mode) (Module, error) {
+	var e *C.char
+	var errnum C.int
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	m := C.dlopenerrno(cname, C.int(openMode(name, mode)), &e, &errnum)
+	if m == nil {
+		return 0, withErrno(takeError(e), syscall.Errno(errnum))
+	}
+	return Module(m), nil
+}
+
+// errnoError is a dlerror() message along with the errno behind it.
+type errnoError struct {
+	msg		string
+	errno	syscall.Errno
+}
+
+func (e *errnoError) Error() string {
+	return e.msg
+}
+
+func (e *errnoError) Unwrap() error {
+	return e.errno
+}
+
+// withErrno attaches the errno behind err to it, if it can be found: dlopen() isn't documented to set errno at all, and glibc's doesn't, but the message ends with strerror() of the errno when a system call failed.
+// So errno is only trusted when the message names it, and otherwise the message is read instead: Go's descriptions are the C library's English ones in lower case, so in other locales nothing is found.
+func withErrno(err error, errno syscall.Errno) error {
+	msg := strings.ToLower(err.Error())
+	if errno == 0 || !strings.Contains(msg, strings.ToLower(errno.Error())) {
+		errno = 0
+		if i := strings.LastIndex(msg, ": "); i >= 0 {
+			errno = errnoNamed(msg[i + 2:])
+		}
+	}
+	if errno == 0 {
+		return err
+	}
+	return &errnoError{
+		msg:		err.Error(),
+		errno:	errno,
+	}
+}
+
+// errnoNamed returns the errno described by s, in lower case, or 0 if there is none.
+func errnoNamed(s string) syscall.Errno {
+	for n := syscall.Errno(1); n < 256; n++ {
+		if strings.ToLower(n.Error()) == s {
+			return n
+		}
+	}
+	return 0
+}
+
+// errnoOf finds the errno in err, if there is one.
+func errnoOf(err error) error {
+	var errno syscall.Errno
+
+	if errors.As(err, &errno) {
+		return errno
+	}
+	return nil
+}
+
+func sysopenself(mode Mode) (Module, error) {
+	var e *C.char
+
+	m := C.dlopenerr(nil, C.int(mode), &e)
+	if m == nil {
+		return 0, takeError(e)
+	}
+	return Module(m), nil
+}
+
+func (m Module) sysclose() error {
+	var e *C.char
+
+	if C.dlcloseerr(unsafe.Pointer(m), &e) != 0 {
+		return takeError(e)
+	}
+	return nil
+}
+
+func (m Module) syssymbol(name string) (symbol unsafe.Pointer, err error) {
+	symbol, err = m.dlsym(name)
+	if err != nil {
+		// give the system's symbol naming convention a try before giving up (see altSymbol())
+		if alt := altSymbol(name); alt != "" {
+			if s, err2 := m.dlsym(alt); err2 == nil {
+				return s, nil
+			}
+		}
+		return nil, err
+	}
+	return symbol, nil
+}
+
+func (m Module) dlsym(name string) (symbol unsafe.Pointer, err error) {
+	var e *C.char
+	var buf [shortName]byte
+
+	if len(name) < len(buf) {
+		symbol = C.dlsymerr(unsafe.Pointer(m), cstring(name, &buf), &e)
+	} else {
+		cname := C.CString(name)
+		symbol = C.dlsymerr(unsafe.Pointer(m), cname, &e)
+		C.free(unsafe.Pointer(cname))
+	}
+	if symbol == nil {
+		if e == nil {		// no error; symbol value is NULL
+			return nil, nil
+		}
+		return nil, takeError(e)
+	}
+	return symbol, nil
+}
+
+// sysprobe is syssymbol without the error, so a missing symbol costs nothing to report.
+func (m Module) sysprobe(name string) (unsafe.Pointer, bool) {
+	if s, ok := m.dlsymok(name); ok {
+		return s, true
+	}
+	if alt := altSymbol(name); alt != "" {
+		return m.dlsymok(alt)
+	}
+	return nil, false
+}
+
+func (m Module) dlsymok(name string) (symbol unsafe.Pointer, ok bool) {
+	var found C.int
+	var buf [shortName]byte
+
+	if len(name) < len(buf) {
+		symbol = C.dlsymok(unsafe.Pointer(m), cstring(name, &buf), &found)
+	} else {
+		cname := C.CString(name)
+		symbol = C.dlsymok(unsafe.Pointer(m), cname, &found)
+		C.free(unsafe.Pointer(cname))
+	}
+	return symbol, found != 0
+}
+
+// shortName is the size of the buffer dlsym uses so it doesn't have to allocate a C string for each lookup; nearly every symbol name fits.
+const shortName = 128
+
+// cstring copies name, which must be shorter than buf, into buf as a C string, so it needs no allocation.
+// This is only for C functions marked noescape, which promise not to keep the pointer, since buf is usually on the stack.
+func cstring(name string, buf *[shortName]byte) *C.char {
+	copy(buf[:], name)
+	buf[len(name)] = 0
+	return (*C.char)(unsafe.Pointer(&buf[0]))
+}
+
+// classify works out the kind of failure from a dlerror() message.
+func classify(err error) error {
+	return classifyMessage(err.Error())
+}
@@ -0,0 +1,132 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Windows always resolves a DLL's imports when it is loaded and always keeps each DLL's exports to itself, so Now, Lazy, Global, and Local have no effect; they exist so portable code can name them.
+// They are kept out of the bits LoadLibraryExW() uses.
+const (
+	Now Mode = 1 << 24
+	Lazy Mode = 1 << 25
+	Global Mode = 1 << 26
+	Local Mode = 1 << 27
+)
+
+// These modes are passed straight to LoadLibraryExW() as its dwFlags, and control where Windows looks for the DLL and its dependencies.
+// See the documentation of LoadLibraryEx() on MSDN for what each means and which versions of Windows have it.
+const (
+	LoadWithAlteredSearchPath Mode = 0x00000008		// LOAD_WITH_ALTERED_SEARCH_PATH
+	SearchDLLLoadDir Mode = 0x00000100			// LOAD_LIBRARY_SEARCH_DLL_LOAD_DIR
+	SearchApplicationDir Mode = 0x00000200		// LOAD_LIBRARY_SEARCH_APPLICATION_DIR
+	SearchUserDirs Mode = 0x00000400			// LOAD_LIBRARY_SEARCH_USER_DIRS
+	SearchSystem32 Mode = 0x00000800			// LOAD_LIBRARY_SEARCH_SYSTEM32
+	SearchDefaultDirs Mode = 0x00001000			// LOAD_LIBRARY_SEARCH_DEFAULT_DIRS
+)
+
+func init() {
+	modeNames = append(modeNames,
+		modeName{ LoadWithAlteredSearchPath, "LoadWithAlteredSearchPath" },
+		modeName{ SearchDLLLoadDir, "SearchDLLLoadDir" },
+		modeName{ SearchApplicationDir, "SearchApplicationDir" },
+		modeName{ SearchUserDirs, "SearchUserDirs" },
+		modeName{ SearchSystem32, "SearchSystem32" },
+		modeName{ SearchDefaultDirs, "SearchDefaultDirs" })
+	// LoadLibraryEx() fails if LOAD_WITH_ALTERED_SEARCH_PATH is given with any LOAD_LIBRARY_SEARCH_ flag
+	conflictingModes = append(conflictingModes,
+		[2]Mode{ LoadWithAlteredSearchPath, SearchDLLLoadDir | SearchApplicationDir | SearchUserDirs | SearchSystem32 | SearchDefaultDirs })
+}
+
+// GetProcAddress() is safe to call from any number of threads at once, and syscall takes GetLastError() in the same call.
+const threadLocalDlerror = true
+
+// unixModes are the bits of Mode that must not be passed to LoadLibraryExW().
+const unixModes = Now | Lazy | Global | Local
+
+var (
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+	loadLibraryExW = kernel32.NewProc("LoadLibraryExW")
+	getModuleHandleExW = kernel32.NewProc("GetModuleHandleExW")
+)
+
+func sysopen(name string, mode Mode) (Module, error) {
+	wname, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	h, _, err := loadLibraryExW.Call(uintptr(unsafe.Pointer(wname)), 0, uintptr(mode &^ unixModes))
+	if h == 0 {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return Module(h), nil
+}
+
+// GetModuleHandleExW() with no flags takes a reference to the executable that FreeLibrary() will drop, so Close works the same as for any other Module.
+func sysopenself(mode Mode) (Module, error) {
+	var h syscall.Handle
+
+	r, _, err := getModuleHandleExW.Call(0, 0, uintptr(unsafe.Pointer(&h)))
+	if r == 0 {
+		return 0, err
+	}
+	return Module(h), nil
+}
+
+// Windows has no pseudo-Modules.
+func (m Module) pseudo() bool {
+	return false
+}
+
+func (m Module) sysclose() error {
+	return syscall.FreeLibrary(syscall.Handle(m))
+}
+
+func (m Module) syssymbol(name string) (unsafe.Pointer, error) {
+	p, err := syscall.GetProcAddress(syscall.Handle(m), name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return unsafe.Pointer(p), nil
+}
+
+func (m Module) sysprobe(name string) (unsafe.Pointer, bool) {
+	p, err := syscall.GetProcAddress(syscall.Handle(m), name)
+	if err != nil {
+		return nil, false
+	}
+	return unsafe.Pointer(p), true
+}
+
+const (
+	errorModNotFound syscall.Errno = 126		// ERROR_MOD_NOT_FOUND
+	errorProcNotFound syscall.Errno = 127		// ERROR_PROC_NOT_FOUND
+	errorBadExeFormat syscall.Errno = 193		// ERROR_BAD_EXE_FORMAT
+)
+
+// errnoOf finds the Windows error code in err, if there is one.
+func errnoOf(err error) error {
+	var errno syscall.Errno
+
+	if errors.As(err, &errno) {
+		return errno
+	}
+	return nil
+}
+
+// classify works out the kind of failure from the Windows error code.
+func classify(err error) error {
+	switch {
+	case errors.Is(err, errorModNotFound), errors.Is(err, syscall.ERROR_FILE_NOT_FOUND), errors.Is(err, syscall.ERROR_PATH_NOT_FOUND):
+		return ErrNotFound
+	case errors.Is(err, errorBadExeFormat):
+		return ErrBadFormat
+	case errors.Is(err, errorProcNotFound):
+		return ErrSymbolNotFound
+	}
+	return nil
+}
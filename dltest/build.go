@@ -0,0 +1,75 @@
+// 14 october 2026
+
+package dltest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// Build compiles source, which is C, into a shared library in a temporary directory that is removed when the test ends, and returns the library's path.
+// This lets tests load a library with exactly the symbols they need instead of relying on something like libm being installed.
+// Any flags, such as "-lm" or "-DNAME=value", are passed to the compiler after the standard ones.
+// The compiler is $CC if set, or else cc from $PATH; if there isn't one, the test is skipped rather than failed, so tests using Build still pass on machines without a C toolchain.
+// If the compiler is found but compiling fails, the test fails with the compiler's output.
+func Build(t testing.TB, source string, flags ...string) string {
+	t.Helper()
+
+	cc, err := compiler()
+	if err != nil {
+		t.Skipf("dltest: no C compiler to build a test library with: %v", err)
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "lib.c")
+	if err := os.WriteFile(src, []byte(source), 0600); err != nil {
+		t.Fatalf("dltest: error writing test library source: %v", err)
+	}
+	out := filepath.Join(dir, libraryFile())
+
+	args := cc[1:]
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		args = append(args, "-dynamiclib")
+	case "windows":
+		args = append(args, "-shared")
+	default:
+		args = append(args, "-shared", "-fPIC")
+	}
+	args = append(args, "-o", out, src)
+	args = append(args, flags...)
+	cmd := exec.Command(cc[0], args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("dltest: error building test library: %v\n%s", err, output)
+	}
+	return out
+}
+
+// compiler returns the command line of the C compiler to use, split into words the way $CC usually is.
+func compiler() ([]string, error) {
+	cc := strings.Fields(os.Getenv("CC"))
+	if len(cc) == 0 {
+		cc = []string{"cc"}
+	}
+	path, err := exec.LookPath(cc[0])
+	if err != nil {
+		return nil, err
+	}
+	cc[0] = path
+	return cc, nil
+}
+
+// libraryFile returns the file name to give the built library, with the extension the system uses.
+func libraryFile() string {
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		return "libtest.dylib"
+	case "windows":
+		return "test.dll"
+	}
+	return "libtest.so"
+}
@@ -0,0 +1,151 @@
+// 14 october 2026
+
+/*
+Package dltest helps test code that uses package dl.
+*/
+package dltest
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"github.com/andlabs/dl"
+)
+
+// Fake is a dl.Loader whose libraries are maps of symbol names to values, so code written against dl.Loader can be tested without real libraries.
+// The values are usually pointers to Go variables; remember that calling a Go function through a C function pointer does not work, so fake the code that calls symbols too.
+// A Fake is safe for concurrent use.
+type Fake struct {
+	lock	sync.Mutex
+	libs	map[string]map[string]unsafe.Pointer
+	self	map[string]unsafe.Pointer
+	opens	map[string]int
+}
+
+// NewFake returns a Fake with no libraries.
+func NewFake() *Fake {
+	return &Fake{
+		libs:	make(map[string]map[string]unsafe.Pointer),
+		self:	make(map[string]unsafe.Pointer),
+		opens:	make(map[string]int),
+	}
+}
+
+// Add makes Open succeed for name and serve the given symbols; symbols may be nil for a library with no symbols.
+// Adding the same name again replaces its symbols; libraries already open keep the old ones.
+func (f *Fake) Add(name string, symbols map[string]unsafe.Pointer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.libs[name] = symbols
+}
+
+// AddSelf adds symbols to those served by OpenSelf.
+func (f *Fake) AddSelf(symbols map[string]unsafe.Pointer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for name, s := range symbols {
+		f.self[name] = s
+	}
+}
+
+// Open opens a library previously given to Add, and fails with an error wrapping dl.ErrNotFound for any other name.
+// mode is checked for unsupported and contradictory modes the same way dl.Open does, but otherwise ignored.
+func (f *Fake) Open(name string, mode dl.Mode) (dl.ModuleHandle, error) {
+	if err := check(mode); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	syms, ok := f.libs[name]
+	if !ok {
+		return nil, &dl.Error{
+			Op:		"open",
+			Library:	name,
+			Err:		dl.ErrNotFound,
+			Msg:		name + ": cannot open shared object file: no such fake library",
+		}
+	}
+	f.opens[name]++
+	return &module{
+		f:		f,
+		name:	name,
+		syms:	syms,
+	}, nil
+}
+
+// OpenSelf opens the fake program, which serves the symbols given to AddSelf.
+func (f *Fake) OpenSelf(mode dl.Mode) (dl.ModuleHandle, error) {
+	if err := check(mode); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.opens[""]++
+	return &module{
+		f:		f,
+		syms:	f.self,
+	}, nil
+}
+
+// Opens returns how many times name has been opened and not closed; use it to check that the code under test closes what it opens.
+// Use the empty string for OpenSelf.
+func (f *Fake) Opens(name string) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.opens[name]
+}
+
+// check rejects the modes dl.Open would reject on every system.
+func check(mode dl.Mode) error {
+	if !mode.Supported() {
+		return dl.ErrUnsupported
+	}
+	if mode & dl.Now != 0 && mode & dl.Lazy != 0 {
+		return fmt.Errorf("%w %v: Now and Lazy cannot be combined", dl.ErrInvalidMode, mode)
+	}
+	return nil
+}
+
+type module struct {
+	f		*Fake
+	name	string
+	syms	map[string]unsafe.Pointer		// for OpenSelf, the Fake's own map, so later AddSelf calls are seen
+	closed	bool
+}
+
+func (m *module) Symbol(name string) (unsafe.Pointer, error) {
+	m.f.lock.Lock()
+	defer m.f.lock.Unlock()
+
+	if m.closed {
+		return nil, dl.ErrClosed
+	}
+	s, ok := m.syms[name]
+	if !ok {
+		return nil, &dl.Error{
+			Op:		"symbol",
+			Library:	m.name,
+			Symbol:	name,
+			Err:		dl.ErrSymbolNotFound,
+			Msg:		m.name + ": undefined symbol: " + name,
+		}
+	}
+	return s, nil
+}
+
+func (m *module) Close() error {
+	m.f.lock.Lock()
+	defer m.f.lock.Unlock()
+
+	if m.closed {
+		return dl.ErrClosed
+	}
+	m.closed = true
+	m.f.opens[m.name]--
+	return nil
+}
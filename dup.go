@@ -0,0 +1,64 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"time"
+)
+
+// Dup takes another reference to m from the system and returns it, so that an independent part of a program can hold the library open and close it on its own schedule.
+// The result is the same Module as m, since the system hands out one handle per library, and it must be closed once on top of however many times m is.
+// The library is found again from the file m was loaded from (see Path), without being loaded a second time; if that is no longer the same library, Dup fails.
+// Dup of a pseudo-Module returns it unchanged.
+func (m Module) Dup() (Module, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.Dup)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if m.pseudo() {
+		return m, nil
+	}
+	if err := m.closedError("open", ""); err != nil {
+		return 0, err
+	}
+	var dup Module
+	var err error
+
+	start := time.Now()
+	name, mode := "", Mode(0)
+	mi := modules[m]
+	if mi != nil {
+		name, mode = mi.name, mi.mode
+	}
+	if mi != nil && name == "" {
+		dup, err = sysopenself(mode)		// from OpenSelf
+	} else if path, perr := m.path(); perr != nil {
+		err = perr
+	} else {
+		if name == "" {
+			name = path		// not opened through this package
+		}
+		dup, err = sysdup(path)
+	}
+	if err == nil && dup != m {
+		dup.sysclose()
+		err = fmt.Errorf("dl: %s now holds another library", name)
+	}
+	if err != nil {
+		err = &Error{
+			Op:		"open",
+			Library:	name,
+			Err:		classify(err),
+			Msg:		"dl: could not take another reference to " + name + ": " + err.Error(),
+		}
+		trace("open", name, "", 0, start, err)
+		return 0, err
+	}
+	opened(m, name, mode, false)
+	share(m)
+	trace("open", name, "", m, start, nil)
+	return m, nil
+}
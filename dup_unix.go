@@ -0,0 +1,32 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// #ifndef RTLD_NOLOAD
+// #define RTLD_NOLOAD 0
+// #endif
+// /* Lazy never undoes an earlier Now, and leaving out Global never undoes an earlier Global, so this changes nothing about the library */
+// #define dupMode (RTLD_LAZY | RTLD_NOLOAD)
+// extern void *dlopenerr(const char *name, int mode, char **err);		/* in dl_unix.go */
+import "C"
+
+// sysdup takes another reference to the library loaded from path, without loading it if it isn't loaded; where there is no RTLD_NOLOAD, it may be loaded, and Dup will notice it's the wrong one.
+func sysdup(path string) (Module, error) {
+	var e *C.char
+
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	h := C.dlopenerr(cpath, C.dupMode, &e)
+	if h == nil {
+		return 0, takeError(e)
+	}
+	return Module(h), nil
+}
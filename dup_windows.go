@@ -0,0 +1,8 @@
+// 14 october 2026
+
+package dl
+
+// LoadLibraryExW() of a full path that is already loaded just takes another reference.
+func sysdup(path string) (Module, error) {
+	return sysopen(path, 0)
+}
@@ -0,0 +1,51 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestForbidDuplicateOpen(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	dl.SetForbidDuplicateOpen(true)
+	defer dl.SetForbidDuplicateOpen(false)
+
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+
+	_, err = dl.Open(lib, dl.Now)
+	if !errors.Is(err, dl.ErrAlreadyOpen) {
+		m.Close()
+		t.Fatalf("second Open error = %v; want ErrAlreadyOpen", err)
+	}
+	var e *dl.DuplicateOpenError
+	if !errors.As(err, &e) {
+		t.Fatalf("second Open error = %T; want *DuplicateOpenError", err)
+	}
+	if !strings.HasPrefix(e.Site, "duplicate_test.go:") && !strings.Contains(e.Site, "/duplicate_test.go:") {
+		t.Errorf("Site = %q; want the first Open in duplicate_test.go", e.Site)
+	}
+
+	// the failed Open must not have left an extra reference behind
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := m.Close(); !errors.Is(err, dl.ErrClosed) {
+		t.Errorf("second Close error = %v; want ErrClosed", err)
+	}
+	m, err = dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open after closing: %v", err)
+	}
+	m.Close()
+}
@@ -0,0 +1,23 @@
+// 14 october 2026
+
+//go:build !darwin && !windows && !aix
+
+package dl
+
+// altName returns another name to try if name fails to load, or an empty string if there is none.
+// Only OS X has one; see dylibName() in dl_darwin.go.
+func altName(name string) string {
+	return ""
+}
+
+// altSymbol returns another name to try if the symbol name isn't found, or an empty string if there is none.
+// Only OS X has one; see altSymbol() in dl_darwin.go.
+func altSymbol(name string) string {
+	return ""
+}
+
+// openMode returns the mode to actually pass to dlopen() for name.
+// Only AIX changes it; see openMode() in dl_aix.go.
+func openMode(name string, mode Mode) Mode {
+	return mode
+}
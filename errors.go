@@ -0,0 +1,170 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnsupported is returned by functions that cannot be implemented on the current system.
+var ErrUnsupported = errors.New("dl: operation not supported on this system")
+
+// These are the kinds of failure an *Error can report; use errors.Is to test for them.
+var (
+	// ErrNotFound means the library, or one of the libraries it depends on, does not exist.
+	ErrNotFound = errors.New("dl: library not found")
+
+	// ErrBadFormat means the library is not a shared object this process can load; for instance, it's not one at all, or it was built for another architecture.
+	ErrBadFormat = errors.New("dl: library has a bad format")
+
+	// ErrSymbolNotFound means the symbol is not defined by the library or its dependencies.
+	ErrSymbolNotFound = errors.New("dl: symbol not found")
+)
+
+// Error is returned when the system fails to open a library, find a symbol, or close a library.
+type Error struct {
+	Op		string		// "open", "symbol", or "close"; or "info" for a Module that was used after it was closed
+	Library	string		// the name the library was opened with, if known
+	Symbol	string		// for "symbol", the symbol looked up
+
+	// Err is the kind of failure: one of ErrNotFound, ErrBadFormat, ErrSymbolNotFound, or ErrClosed, or nil if it couldn't be worked out.
+	Err		error
+
+	// Msg is the system's own description of the error, such as what dlerror() returned.
+	// It is not meant to be parsed; use Err instead.
+	Msg		string
+
+	// For "open", Path is the file the system was actually asked to load (after Resolve, if a policy or SetResolver is in effect), and Mode is the mode it was given.
+	Path		string
+	Mode	Mode
+
+	// Errno is the system error underneath the failure, such as syscall.ENOENT or syscall.EACCES, or nil if there is none or it couldn't be found out.
+	// On Unix it is only known for opens, and is worked out from the dynamic linker's message, so it is only found in English locales; on Windows it is the error LoadLibraryExW() or GetProcAddress() returned.
+	Errno	error
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// Unwrap returns Err, or, if Errno is also set, an error that is Err but wraps Errno, so errors.Is and errors.As can test for either; for instance, errors.Is(err, fs.ErrPermission).
+func (e *Error) Unwrap() error {
+	if e.Errno == nil {
+		return e.Err
+	}
+	if e.Err == nil {
+		return e.Errno
+	}
+	return &kindError{
+		kind:	e.Err,
+		errno:	e.Errno,
+	}
+}
+
+// kindError is what Error.Unwrap returns when an *Error has both an Err and an Errno: it stands for the Err, and wraps the Errno, so a single chain leads through both.
+type kindError struct {
+	kind		error
+	errno	error
+}
+
+func (e *kindError) Error() string {
+	return e.kind.Error()
+}
+
+func (e *kindError) Is(target error) bool {
+	return errors.Is(e.kind, target)
+}
+
+func (e *kindError) As(target interface{}) bool {
+	return errors.As(e.kind, target)
+}
+
+func (e *kindError) Unwrap() error {
+	return e.errno
+}
+
+// libraryName returns the name m was opened with, if it was opened through this package.
+// The caller must hold dllock.
+func (m Module) libraryName() string {
+	if mi := modules[m]; mi != nil {
+		return mi.name
+	}
+	return ""
+}
+
+// openError makes the *Error for a failed attempt to load path with mode, which was asked for as name.
+// The kind of failure is worked out by looking at the file itself where possible, since error messages vary by system and locale, and from the message otherwise.
+func openError(name string, path string, mode Mode, err error) error {
+	var kind error
+	if errors.Is(err, ErrUnsupported) {
+		kind = ErrUnsupported		// nothing can be loaded on this system; see dl_stub.go
+	} else {
+		kind = diagnoseOpen(path)
+	}
+	if kind == nil {
+		kind = classify(err)
+	}
+	return &Error{
+		Op:		"open",
+		Library:	name,
+		Err:		kind,
+		Msg:		err.Error(),
+		Path:	path,
+		Mode:	mode,
+		Errno:	errnoOf(err),
+	}
+}
+
+// symbolError makes the *Error for a failed lookup of name in m.
+// dlsym() has only one way to fail, so there's no need to look at the message.
+// The caller must hold dllock.
+func (m Module) symbolError(name string, err error) error {
+	return &Error{
+		Op:		"symbol",
+		Library:	m.libraryName(),
+		Symbol:	name,
+		Err:		ErrSymbolNotFound,
+		Msg:		err.Error(),
+		Errno:	errnoOf(err),
+	}
+}
+
+// closeError makes the *Error for a failed close of m.
+// The caller must hold dllock.
+func (m Module) closeError(err error) error {
+	return &Error{
+		Op:		"close",
+		Library:	m.libraryName(),
+		Msg:		err.Error(),
+	}
+}
+
+// messageKinds maps fragments of the English error messages of glibc, musl, the BSDs, and dyld to the kinds of failure they describe.
+// This is the last resort; see openError.
+var messageKinds = []struct {
+	fragment	string
+	kind		error
+}{
+	{ "no such file", ErrNotFound },
+	{ "image not found", ErrNotFound },
+	{ "file not found", ErrNotFound },
+	{ "invalid elf header", ErrBadFormat },
+	{ "wrong elf class", ErrBadFormat },
+	{ "file too short", ErrBadFormat },
+	{ "exec format error", ErrBadFormat },
+	{ "only et_dyn and et_exec", ErrBadFormat },
+	{ "not a mach-o file", ErrBadFormat },
+	{ "incompatible architecture", ErrBadFormat },
+	{ "unsupported file format", ErrBadFormat },
+}
+
+func classifyMessage(msg string) error {
+	msg = strings.ToLower(msg)
+	for _, k := range messageKinds {
+		if strings.Contains(msg, k.fragment) {
+			return k.kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// 14 october 2026
+
+package dl_test
+
+import (
+	"errors"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	"github.com/andlabs/dl"
+)
+
+func TestErrorUnwrap(t *testing.T) {
+	err := error(&dl.Error{
+		Op:		"open",
+		Err:		dl.ErrNotFound,
+		Msg:		"dl: libnothere.so: cannot open shared object file: No such file or directory",
+		Errno:	syscall.ENOENT,
+	})
+	if !errors.Is(err, dl.ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false; want true")
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("errors.Is(err, fs.ErrNotExist) = false; want true")
+	}
+	var errno syscall.Errno
+	if !errors.As(err, &errno) || errno != syscall.ENOENT {
+		t.Errorf("errors.As(err, &errno) gave %v; want ENOENT", errno)
+	}
+	kind := errors.Unwrap(err)
+	if kind == nil || !errors.Is(kind, dl.ErrNotFound) {
+		t.Errorf("errors.Unwrap(err) = %v; want something that is ErrNotFound", kind)
+	}
+	if errors.Unwrap(kind) != syscall.ENOENT {
+		t.Errorf("errors.Unwrap(errors.Unwrap(err)) = %v; want ENOENT", errors.Unwrap(kind))
+	}
+
+	err = &dl.Error{
+		Op:		"open",
+		Err:		dl.ErrNotFound,
+	}
+	if errors.Unwrap(err) != dl.ErrNotFound {
+		t.Errorf("errors.Unwrap(err) without an Errno = %v; want ErrNotFound", errors.Unwrap(err))
+	}
+}
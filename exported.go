@@ -0,0 +1,102 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"fmt"
+)
+
+// ExportedSymbol describes a symbol a Module exports, as found in its dynamic symbol table.
+type ExportedSymbol struct {
+	Name		string
+	Version	string		// the symbol's version, or an empty string if it isn't versioned
+	Type		elf.SymType	// such as elf.STT_FUNC or elf.STT_OBJECT
+	Size		uint64
+	Addr		uintptr		// where the symbol is in memory; 0 for thread-local symbols, which have a separate address in each thread
+}
+
+// ExportedSymbols lists every symbol m defines and makes visible to other objects, by reading the dynamic symbol table of the file m was loaded from.
+// This only works on systems that use ELF, and needs the path from Info, so it returns ErrUnsupported where that isn't available.
+func (m Module) ExportedSymbols() ([]ExportedSymbol, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.ExportedSymbols)
+	}
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	f, err := elf.Open(o.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	syms, err := f.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	var exported []ExportedSymbol
+	for _, s := range syms {
+		if !isExported(s) {
+			continue
+		}
+		e := ExportedSymbol{
+			Name:	s.Name,
+			Version:	s.Version,
+			Type:	elf.ST_TYPE(s.Info),
+			Size:		s.Size,
+		}
+		if e.Type != elf.STT_TLS {
+			e.Addr = o.bias + uintptr(s.Value)
+		}
+		exported = append(exported, e)
+	}
+	return exported, nil
+}
+
+// isExported returns whether s is defined in its file and visible from other files.
+func isExported(s elf.Symbol) bool {
+	if s.Section == elf.SHN_UNDEF || s.Name == "" {
+		return false
+	}
+	switch elf.ST_BIND(s.Info) {
+	case elf.STB_GLOBAL, elf.STB_WEAK, elf.STB_LOOS:		// STB_LOOS is STB_GNU_UNIQUE
+	default:
+		return false
+	}
+	switch elf.ST_VISIBILITY(s.Other) {
+	case elf.STV_DEFAULT, elf.STV_PROTECTED:
+		return true
+	}
+	return false
+}
+
+// SymbolVersions lists the versions of the named symbol that m defines, from its symbol version table, in the order they appear in the file, so you can choose one to pass to SymbolVersion.
+// The list is empty if the symbol isn't versioned, and an error wrapping ErrSymbolNotFound is returned if m doesn't define it at all.
+// Like ExportedSymbols, this only works on ELF systems.
+func (m Module) SymbolVersions(name string) ([]string, error) {
+	syms, err := m.ExportedSymbols()
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	versions := []string{}
+	for _, s := range syms {
+		if s.Name != name {
+			continue
+		}
+		found = true
+		if s.Version != "" {
+			versions = append(versions, s.Version)
+		}
+	}
+	if !found {
+		dllock.RLock()
+		defer dllock.RUnlock()
+		return nil, m.symbolError(name, fmt.Errorf("dl: %s does not define %s", m.libraryName(), name))
+	}
+	return versions, nil
+}
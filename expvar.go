@@ -0,0 +1,82 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// These are always kept up to date, even if PublishExpvars() is never called; expvar.Int is already atomic, so there's no need to take dllock to touch them.
+var (
+	nopens		expvar.Int
+	ncloses		expvar.Int
+	nopenerrors	expvar.Int
+	opentime		expvar.Int		// cumulative, in nanoseconds
+	openfailures	= new(expvar.Map).Init()	// by library name; see countFailure
+)
+
+// maxFailureNames is how many libraries openfailures lists by name, so that a program that tries to open many different names (or names that come from its input) can't make it grow without bound.
+// Once it is full, failures of any other library are counted under otherFailures.
+const maxFailureNames = 100
+
+const otherFailures = "(other)"
+
+// failureNames counts the names in openfailures other than otherFailures; it is guarded by failureLock, which keeps the check and the add together.
+var failureNames int
+var failureLock sync.Mutex
+
+var publishOnce sync.Once
+
+// PublishExpvars publishes statistics about the package's activity through package expvar, as a map named "dl" with the following keys:
+// 	opens		number of successful calls to Open() and OpenSelf()
+// 	closes		number of successful calls to Close()
+// 	open		number of Modules currently open (opens - closes)
+// 	openerrors	number of failed calls to Open() and OpenSelf()
+// 	opentime		total time spent in dlopen(), in nanoseconds
+// 	failures		a map from library name to the number of failed opens of that library
+// The names in failures are as passed to Open, or "(self)" for OpenSelf; only the first 100 different libraries to fail are listed by name, and failures of any others are all counted under "(other)", so the map stays small however many names are tried.
+// The statistics are collected from the start of the program regardless of when (or whether) PublishExpvars is called.
+// It is safe to call PublishExpvars more than once; only the first call has any effect.
+func PublishExpvars() {
+	publishOnce.Do(func() {
+		m := new(expvar.Map).Init()
+		m.Set("opens", &nopens)
+		m.Set("closes", &ncloses)
+		m.Set("open", expvar.Func(func() interface{} {
+			return nopens.Value() - ncloses.Value()
+		}))
+		m.Set("openerrors", &nopenerrors)
+		m.Set("opentime", &opentime)
+		m.Set("failures", openfailures)
+		expvar.Publish("dl", m)
+	})
+}
+
+// countOpen records the result of a dlopen() that began at start.
+func countOpen(start time.Time, ok bool) {
+	opentime.Add(int64(time.Since(start)))
+	if ok {
+		nopens.Add(1)
+	} else {
+		nopenerrors.Add(1)
+	}
+}
+
+// countFailure records a failed open of the named library.
+func countFailure(library string) {
+	if library == "" {
+		library = "(self)"
+	}
+	failureLock.Lock()
+	defer failureLock.Unlock()
+	if openfailures.Get(library) == nil {
+		if failureNames >= maxFailureNames {
+			library = otherFailures
+		} else {
+			failureNames++
+		}
+	}
+	openfailures.Add(library, 1)
+}
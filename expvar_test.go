@@ -0,0 +1,97 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"expvar"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/andlabs/dl"
+)
+
+// dlVar returns the value of the named statistic in the "dl" expvar map.
+func dlVar(t *testing.T, name string) int64 {
+	t.Helper()
+	m, ok := expvar.Get("dl").(*expvar.Map)
+	if !ok {
+		t.Fatalf("no dl map published")
+	}
+	v := m.Get(name)
+	if v == nil {
+		t.Fatalf("dl map has no %s", name)
+	}
+	n, err := strconv.ParseInt(v.String(), 10, 64)
+	if err != nil {
+		t.Fatalf("dl.%s = %s; want an integer", name, v)
+	}
+	return n
+}
+
+func TestPublishExpvars(t *testing.T) {
+	dl.PublishExpvars()
+	dl.PublishExpvars()		// must not panic by publishing twice
+
+	opens, closes, errs := dlVar(t, "opens"), dlVar(t, "closes"), dlVar(t, "openerrors")
+	m := openSymbolLibrary(t)
+	if got := dlVar(t, "opens"); got != opens + 1 {
+		t.Errorf("opens = %d after an Open; want %d", got, opens + 1)
+	}
+	if got := dlVar(t, "open"); got != dlVar(t, "opens") - dlVar(t, "closes") {
+		t.Errorf("open = %d; want opens - closes", got)
+	}
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := dlVar(t, "closes"); got != closes + 1 {
+		t.Errorf("closes = %d after a Close; want %d", got, closes + 1)
+	}
+
+	failures := expvar.Get("dl").(*expvar.Map).Get("failures").(*expvar.Map)
+	other := count(failures.Get("(other)"))
+	missing := filepath.Join(t.TempDir(), "libnothere.so")
+	if _, err := dl.Open(missing, dl.Now); err == nil {
+		t.Fatalf("Open(%q) succeeded", missing)
+	}
+	if got := dlVar(t, "openerrors"); got != errs + 1 {
+		t.Errorf("openerrors = %d after a failed Open; want %d", got, errs + 1)
+	}
+	// once earlier tests (or earlier runs under -count) have filled failures, new names go under (other)
+	if v := failures.Get(missing); v == nil {
+		if got := count(failures.Get("(other)")); got != other + 1 {
+			t.Errorf("failures has neither %q nor an (other) of %d; (other) = %d", missing, other + 1, got)
+		}
+	} else if v.String() != "1" {
+		t.Errorf("failures[%q] = %v; want 1", missing, v)
+	}
+}
+
+// count returns the value of an *expvar.Int in failures, or 0 if it is not there yet.
+func count(v expvar.Var) int64 {
+	if v == nil {
+		return 0
+	}
+	return v.(*expvar.Int).Value()
+}
+
+func TestFailuresCapped(t *testing.T) {
+	dl.PublishExpvars()
+	dir := t.TempDir()
+	for i := 0; i < 150; i++ {
+		dl.Open(filepath.Join(dir, "libnothere" + strconv.Itoa(i) + ".so"), dl.Now)
+	}
+	failures := expvar.Get("dl").(*expvar.Map).Get("failures").(*expvar.Map)
+	n := 0
+	failures.Do(func(kv expvar.KeyValue) {
+		n++
+	})
+	if n > 101 {
+		t.Errorf("failures has %d keys; want at most 100 names and (other)", n)
+	}
+	if failures.Get("(other)") == nil {
+		t.Errorf("failures has no (other) after 150 different failures")
+	}
+}
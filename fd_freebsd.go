@@ -0,0 +1,58 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"time"
+)
+
+// #include <dlfcn.h>
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// static void *fdlopenerr(int fd, int mode, char **err)
+// {
+// 	void *h;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	h = fdlopen(fd, mode);
+// 	if (h == NULL)
+// 		*err = dlerrordup();
+// 	return h;
+// }
+import "C"
+
+func init() {
+	features[FeatureOpenFd] = true
+}
+
+// OpenFd opens the library in the open file fd; for instance, one received over a Unix socket.
+// This is fdlopen(); the file is named /dev/fd/N in errors.
+// fd can be closed once OpenFd returns.
+// Only Linux and FreeBSD can do this; elsewhere, OpenFd returns ErrUnsupported.
+func OpenFd(fd uintptr, mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	start := time.Now()
+	name := fmt.Sprintf("/dev/fd/%d", fd)
+	if err := checkPolicy(name, name); err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	var e *C.char
+	m := C.fdlopenerr(C.int(fd), C.int(mode), &e)
+	if m == nil {
+		err := takeError(e)
+		return finishOpen(name, mode, start, 0, &Error{		// not openError(); the name isn't a file to look at
+			Op:		"open",
+			Library:	name,
+			Err:		classify(err),
+			Msg:		err.Error(),
+		})
+	}
+	return finishOpen(name, mode, start, Module(m), nil)
+}
@@ -0,0 +1,36 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"time"
+)
+
+func init() {
+	features[FeatureOpenFd] = true
+}
+
+// OpenFd opens the library in the open file fd; for instance, one received over a Unix socket.
+// On Linux the file is opened under the name /proc/self/fd/N, which is also the name Path and errors use.
+// The dynamic linker recognizes a library it already has by that name, so keep fd open for as long as the library is open; otherwise a different library later passed in under the same descriptor number will be mistaken for this one.
+// Only Linux and FreeBSD can do this; elsewhere, OpenFd returns ErrUnsupported.
+func OpenFd(fd uintptr, mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	start := time.Now()
+	path := fmt.Sprintf("/proc/self/fd/%d", fd)
+	if err := checkPolicy(path, path); err != nil {
+		return finishOpen(path, mode, start, 0, err)
+	}
+	m, err := sysopen(path, mode)
+	if err != nil {
+		err = openError(path, path, mode, err)
+	}
+	return finishOpen(path, mode, start, m, err)
+}
@@ -0,0 +1,11 @@
+// 14 october 2026
+
+//go:build !linux && !freebsd
+
+package dl
+
+// OpenFd opens the library in the open file fd.
+// Only Linux and FreeBSD can do this; on this system, OpenFd returns ErrUnsupported.
+func OpenFd(fd uintptr, mode Mode) (Module, error) {
+	return 0, ErrUnsupported
+}
@@ -0,0 +1,29 @@
+// 14 october 2026
+
+package dl
+
+// Feature is something this package can only do on some systems, for Supports.
+type Feature int
+
+const (
+	FeatureNoload		Feature = iota	// the Noload mode
+	FeatureNodelete						// the Nodelete mode
+	FeatureDeepbind						// the Deepbind mode
+	FeatureDlmopen						// OpenNamespace
+	FeatureDlvsym						// Module.SymbolVersion
+	FeatureOpenFd						// OpenFd (fdlopen() or its equivalent)
+	FeatureDlinfo						// Module.Info and everything else that needs to know which file a Module is, such as ExportedSymbols
+	FeatureLoadedObjects					// LoadedObjects and OpenTracked
+	FeatureMemfd						// OpenBytes without a temporary file
+	FeatureTLSInfo						// Module.TLSModuleID and Module.TLSBlock
+)
+
+// features holds the Features this system has; each system's files add their own.
+var features = make(map[Feature]bool)
+
+// Supports returns whether the current system, and the C library the program was built with, can do f.
+// Use it to choose what to do at run time instead of keeping a table of systems and C libraries yourself; the functions involved all return ErrUnsupported otherwise.
+// Modes can also be tested with Mode.Supported; Supports additionally tells whether the Mode exists at all, since compiling code that names a missing Mode fails.
+func Supports(f Feature) bool {
+	return features[f]
+}
@@ -0,0 +1,50 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OpenFramework opens the named framework, such as "CoreAudio", the way a program linked against it would find it.
+// The name can also be given with its ".framework" suffix, or as the path of a .framework bundle.
+// The framework's binary is looked for in each directory of $DYLD_FRAMEWORK_PATH, then ~/Library/Frameworks, /Library/Frameworks, /Network/Library/Frameworks, and /System/Library/Frameworks, and the first that opens is returned.
+// System frameworks have no files on disk on newer versions of OS X (they live in the dyld shared cache), so those are always tried, if nothing else is found first.
+// This is only available on OS X; elsewhere, OpenFramework returns ErrUnsupported.
+func OpenFramework(name string, mode Mode) (Module, error) {
+	var paths []string
+
+	name = strings.TrimSuffix(name, "/")
+	if strings.Contains(name, "/") {
+		bundle := strings.TrimSuffix(filepath.Base(name), ".framework")
+		paths = []string{filepath.Join(name, bundle)}
+	} else {
+		name = strings.TrimSuffix(name, ".framework")
+		for _, dir := range frameworkDirs() {
+			p := filepath.Join(dir, name + ".framework", name)
+			if _, err := os.Stat(p); err == nil || dir == "/System/Library/Frameworks" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	m, _, err := OpenCandidates(paths, mode)
+	return m, err
+}
+
+// frameworkDirs returns the directories dyld searches for frameworks, in order.
+func frameworkDirs() []string {
+	var dirs []string
+
+	if p := os.Getenv("DYLD_FRAMEWORK_PATH"); p != "" {
+		dirs = append(dirs, filepath.SplitList(p)...)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Library/Frameworks"))
+	}
+	return append(dirs,
+		"/Library/Frameworks",
+		"/Network/Library/Frameworks",
+		"/System/Library/Frameworks")
+}
@@ -0,0 +1,11 @@
+// 14 october 2026
+
+//go:build !darwin
+
+package dl
+
+// OpenFramework opens the named OS X framework.
+// There are no frameworks on this system, so it returns ErrUnsupported.
+func OpenFramework(name string, mode Mode) (Module, error) {
+	return 0, ErrUnsupported
+}
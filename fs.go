@@ -0,0 +1,21 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// OpenFS opens the library at path in fsys, which can be an embed.FS holding a library built into the program.
+// The library is copied out exactly like OpenBytes does, and the copy is removed once the last reference to the returned Module is closed.
+func OpenFS(fsys fs.FS, path string, mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return 0, fmt.Errorf("dl: could not read library: %w", err)
+	}
+	return OpenBytes(data, mode)
+}
@@ -0,0 +1,26 @@
+// 14 october 2026
+
+package dl
+
+// CType is a C type that a Func can take or return.
+type CType int
+const (
+	CVoid CType = iota		// only valid as a return type
+	CInt8
+	CUint8
+	CInt16
+	CUint16
+	CInt32
+	CUint32
+	CInt64
+	CUint64
+	CInt					// int
+	CUint				// unsigned int
+	CLong				// long
+	CUlong				// unsigned long
+	CSize				// size_t
+	CFloat				// float
+	CDouble				// double
+	CPointer				// any pointer
+	CString				// const char *
+)
@@ -0,0 +1,131 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+const funcSource = `
+#include <stddef.h>
+#include <string.h>
+int add(int a, int b) { return a + b; }
+signed char neg8(signed char x) { return -x; }
+unsigned short inc16(unsigned short x) { return x + 1; }
+long long mul64(long long a, long long b) { return a * b; }
+double scale(double x, float by) { return x * by; }
+float half(float x) { return x / 2; }
+size_t length(const char *s) { return s == NULL ? (size_t) -1 : strlen(s); }
+const char *greeting(void) { return "hello"; }
+const char *nothing(void) { return NULL; }
+void *same(void *p) { return p; }
+static int counter;
+void bump(void) { counter++; }
+int count(void) { return counter; }
+`
+
+func TestFuncCall(t *testing.T) {
+	lib := dltest.Build(t, funcSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	defer m.Close()
+	newFunc := func(name string, ret dl.CType, args ...dl.CType) *dl.Func {
+		t.Helper()
+		p, err := m.StrictSymbol(name)
+		if err != nil {
+			t.Fatalf("StrictSymbol(%s): %v", name, err)
+		}
+		f, err := dl.NewFunc(p, ret, args...)
+		if err != nil {
+			t.Skipf("NewFunc(%s): %v", name, err)
+		}
+		return f
+	}
+	var x int
+	tests := []struct {
+		name	string
+		f	*dl.Func
+		args	[]interface{}
+		want	interface{}
+	}{
+		{"add", newFunc("add", dl.CInt, dl.CInt, dl.CInt), []interface{}{2, -5}, int64(-3)},
+		{"add of uint8s", newFunc("add", dl.CInt, dl.CInt, dl.CInt), []interface{}{uint8(200), uint8(100)}, int64(300)},
+		{"neg8", newFunc("neg8", dl.CInt8, dl.CInt8), []interface{}{int8(5)}, int64(-5)},
+		{"inc16 wraps", newFunc("inc16", dl.CUint16, dl.CUint16), []interface{}{0xFFFF}, uint64(0)},
+		{"mul64", newFunc("mul64", dl.CInt64, dl.CInt64, dl.CInt64), []interface{}{int64(1) << 40, -3}, int64(-3) << 40},
+		{"scale", newFunc("scale", dl.CDouble, dl.CDouble, dl.CFloat), []interface{}{1.5, float32(4)}, 6.0},
+		{"half", newFunc("half", dl.CFloat, dl.CFloat), []interface{}{float64(3)}, 1.5},
+		{"length", newFunc("length", dl.CSize, dl.CString), []interface{}{"four"}, uint64(4)},
+		{"length of NULL", newFunc("length", dl.CLong, dl.CString), []interface{}{nil}, int64(-1)},
+		{"greeting", newFunc("greeting", dl.CString), nil, "hello"},
+		{"nothing", newFunc("nothing", dl.CString), nil, ""},
+		{"same", newFunc("same", dl.CPointer, dl.CPointer), []interface{}{unsafe.Pointer(&x)}, unsafe.Pointer(&x)},
+		{"same of nil", newFunc("same", dl.CPointer, dl.CPointer), []interface{}{nil}, unsafe.Pointer(nil)},
+	}
+	for _, tt := range tests {
+		got, err := tt.f.Call(tt.args...)
+		if err != nil {
+			t.Errorf("%s: Call: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Call = %#v (%T); want %#v (%T)", tt.name, got, got, tt.want, tt.want)
+		}
+	}
+
+	bump := newFunc("bump", dl.CVoid)
+	if got, err := bump.Call(); err != nil || got != nil {
+		t.Errorf("void Call = %v, %v; want nil, nil", got, err)
+	}
+	if got, _ := newFunc("count", dl.CInt).Call(); got != int64(1) {
+		t.Errorf("count after bump = %v; want 1", got)
+	}
+}
+
+func TestFuncCallErrors(t *testing.T) {
+	lib := dltest.Build(t, funcSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	defer m.Close()
+	p, err := m.StrictSymbol("scale")
+	if err != nil {
+		t.Fatalf("StrictSymbol(scale): %v", err)
+	}
+	if _, err := dl.NewFunc(nil, dl.CInt); err == nil {
+		t.Errorf("NewFunc of nil succeeded")
+	}
+	scale, err := dl.NewFunc(p, dl.CDouble, dl.CDouble, dl.CFloat)
+	if err != nil {
+		t.Skipf("NewFunc: %v", err)
+	}
+	if _, err := dl.NewFunc(p, dl.CDouble, dl.CVoid); err == nil {
+		t.Errorf("NewFunc with a void argument succeeded")
+	}
+	for _, args := range [][]interface{}{
+		{1.0},
+		{1.0, float32(2), 3.0},
+		{1, float32(2)},
+		{"1", float32(2)},
+	} {
+		if _, err := scale.Call(args...); err == nil {
+			t.Errorf("Call(%v) of scale(double, float) succeeded", args)
+		}
+	}
+	length, err := dl.NewFunc(p, dl.CSize, dl.CString)
+	if err != nil {
+		t.Fatalf("NewFunc: %v", err)
+	}
+	if _, err := length.Call(4); err == nil {
+		t.Errorf("Call with an int for a CString succeeded")
+	}
+}
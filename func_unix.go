@@ -0,0 +1,351 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// libffi is loaded at run time, so programs that never make a Func don't need it, and building the package doesn't need its headers.
+// It is only used through pointers, except for these two structures, which libffi has never changed:
+// 	typedef struct _ffi_type { size_t size; unsigned short alignment; unsigned short type; struct _ffi_type **elements; } ffi_type;
+// 	typedef struct { ffi_abi abi; unsigned nargs; ffi_type **arg_types; ffi_type *rtype; unsigned bytes; unsigned flags; FFI_EXTRA_CIF_FIELDS } ffi_cif;
+// ffi_type is never looked inside; ffi_cif only needs enough space, and cifSize is far more than any architecture's extra fields take.
+// What is not stable is the value of FFI_DEFAULT_ABI, so only architectures whose value is known are supported.
+
+// #include <stdlib.h>
+// #if defined(__x86_64__)
+// #define defaultABI 2		/* FFI_UNIX64 */
+// #elif defined(__aarch64__) || defined(__i386__)
+// #define defaultABI 1		/* FFI_SYSV */
+// #else
+// #define defaultABI -1
+// #endif
+// #define cifSize 256
+// typedef int (*prepcif)(void *cif, int abi, unsigned int nargs, void *rtype, void **atypes);
+// typedef void (*call)(void *cif, void (*fn)(void), void *rvalue, void **avalue);
+// static int callprepcif(void *p, void *cif, unsigned int nargs, void *rtype, void **atypes)
+// {
+// 	prepcif f;
+//
+// 	*((void **) (&f)) = p;
+// 	return (*f)(cif, defaultABI, nargs, rtype, atypes);
+// }
+// static void callcall(void *p, void *cif, void *fn, void *rvalue, void **avalue)
+// {
+// 	call f;
+// 	void (*g)(void);
+//
+// 	*((void **) (&f)) = p;
+// 	*((void **) (&g)) = fn;
+// 	(*f)(cif, g, rvalue, avalue);
+// }
+import "C"
+
+var libffi struct {
+	once		sync.Once
+	err		error
+	prepcif	unsafe.Pointer
+	call		unsafe.Pointer
+	types	[CString + 1]unsafe.Pointer
+}
+
+var libffiNames = []string{
+	"libffi.so.8",
+	"libffi.so.7",
+	"libffi.so.6",
+	"libffi.so",
+}
+
+func loadffi() error {
+	libffi.once.Do(func() {
+		if C.defaultABI == -1 {
+			libffi.err = ErrUnsupported
+			return
+		}
+
+		dllock.Lock()
+		defer dllock.Unlock()
+
+		// this is never closed, so don't put it in the package's books; but it is loaded like anything else, so the Resolver and Policy get their say
+		var m Module
+		var denied error
+		for _, name := range libffiNames {
+			var path string
+			path, libffi.err = loadPath(name)
+			if libffi.err != nil {
+				continue
+			}
+			if libffi.err = checkPolicy(name, path); libffi.err != nil {
+				denied = libffi.err
+				continue
+			}
+			m, libffi.err = sysopen(path, Now | Local)
+			if libffi.err == nil {
+				break
+			}
+		}
+		if libffi.err != nil && denied != nil {
+			libffi.err = denied		// more useful than any of the loads that weren't refused
+		}
+		if libffi.err != nil {
+			libffi.err = fmt.Errorf("dl: Func needs libffi: %w", libffi.err)
+			return
+		}
+
+		sym := func(name string) unsafe.Pointer {
+			if libffi.err != nil {
+				return nil
+			}
+			var s unsafe.Pointer
+			s, libffi.err = m.syssymbol(name)
+			return s
+		}
+		libffi.prepcif = sym("ffi_prep_cif")
+		libffi.call = sym("ffi_call")
+		long, ulong, size := "ffi_type_sint64", "ffi_type_uint64", "ffi_type_uint64"
+		if unsafe.Sizeof(C.long(0)) == 4 {
+			long, ulong = "ffi_type_sint32", "ffi_type_uint32"
+		}
+		if unsafe.Sizeof(C.size_t(0)) == 4 {
+			size = "ffi_type_uint32"
+		}
+		for t, name := range map[CType]string{
+			CVoid:		"ffi_type_void",
+			CInt8:		"ffi_type_sint8",
+			CUint8:		"ffi_type_uint8",
+			CInt16:		"ffi_type_sint16",
+			CUint16:		"ffi_type_uint16",
+			CInt32:		"ffi_type_sint32",
+			CUint32:		"ffi_type_uint32",
+			CInt64:		"ffi_type_sint64",
+			CUint64:		"ffi_type_uint64",
+			CInt:			"ffi_type_sint32",
+			CUint:		"ffi_type_uint32",
+			CLong:		long,
+			CUlong:		ulong,
+			CSize:		size,
+			CFloat:		"ffi_type_float",
+			CDouble:		"ffi_type_double",
+			CPointer:		"ffi_type_pointer",
+			CString:		"ffi_type_pointer",
+		} {
+			libffi.types[t] = sym(name)
+		}
+	})
+	return libffi.err
+}
+
+// Func is a C function that can be called directly from Go, without writing a cgo trampoline for it.
+// Calls are made with libffi, which is loaded the first time a Func is made; if it cannot be found, NewFunc fails.
+// Funcs are safe for concurrent use.
+type Func struct {
+	fn		unsafe.Pointer
+	ret		CType
+	args		[]CType
+	cif		unsafe.Pointer		// C memory; owns atypes
+	atypes	unsafe.Pointer
+}
+
+// NewFunc prepares to call the C function fn (as returned by Symbol), which returns ret and takes arguments of the types given in args.
+// Variadic functions are not supported.
+func NewFunc(fn unsafe.Pointer, ret CType, args ...CType) (*Func, error) {
+	if err := loadffi(); err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		return nil, errors.New("dl: NewFunc of nil function")
+	}
+	if ret < CVoid || ret > CString {
+		return nil, fmt.Errorf("dl: invalid return type %d", ret)
+	}
+	for i, a := range args {
+		if a <= CVoid || a > CString {
+			return nil, fmt.Errorf("dl: invalid type %d for argument %d", a, i)
+		}
+	}
+
+	f := &Func{
+		fn:		fn,
+		ret:		ret,
+		args:		append([]CType(nil), args...),
+		cif:		C.calloc(1, C.cifSize),
+		atypes:	C.calloc(C.size_t(len(args) + 1), C.size_t(unsafe.Sizeof(unsafe.Pointer(nil)))),
+	}
+	runtime.SetFinalizer(f, (*Func).free)
+	atypes := unsafe.Slice((*unsafe.Pointer)(f.atypes), len(args) + 1)
+	for i, a := range args {
+		atypes[i] = libffi.types[a]
+	}
+	if C.callprepcif(libffi.prepcif, f.cif, C.uint(len(args)), libffi.types[ret], (*unsafe.Pointer)(f.atypes)) != 0 {
+		return nil, errors.New("dl: libffi could not prepare call")
+	}
+	return f, nil
+}
+
+func (f *Func) free() {
+	C.free(f.cif)
+	C.free(f.atypes)
+}
+
+// Call calls the function with the given arguments, which must be as many as the Func was made with.
+// Each argument is converted to the C type it was declared as:
+// 	integer types		any Go integer
+// 	CFloat, CDouble	float32 or float64
+// 	CPointer		unsafe.Pointer, uintptr, or nil
+// 	CString		string (copied to C memory, which is freed when Call returns) or nil for NULL
+// The result is int64 for signed integer types, uint64 for unsigned ones, float64 for CFloat and CDouble, unsafe.Pointer for CPointer, string for CString (an empty string for NULL), and nil for CVoid.
+// The usual rules about passing Go pointers to C apply to unsafe.Pointer arguments.
+func (f *Func) Call(args ...interface{}) (interface{}, error) {
+	if len(args) != len(f.args) {
+		return nil, fmt.Errorf("dl: Func takes %d arguments; %d given", len(f.args), len(args))
+	}
+
+	// every argument gets an 8-byte slot, which is enough for any of the types; avalue points to each
+	const slot = 8
+	mem := C.calloc(C.size_t(len(args) + 1), slot + C.size_t(unsafe.Sizeof(unsafe.Pointer(nil))))
+	defer C.free(mem)
+	avalue := unsafe.Slice((*unsafe.Pointer)(mem), len(args) + 1)
+	values := unsafe.Add(mem, (len(args) + 1) * int(unsafe.Sizeof(unsafe.Pointer(nil))))
+	for i, a := range args {
+		p := unsafe.Add(values, i * slot)
+		avalue[i] = p
+		cs, err := setArg(p, f.args[i], a)
+		if err != nil {
+			return nil, fmt.Errorf("dl: argument %d: %w", i, err)
+		}
+		if cs != nil {
+			defer C.free(cs)
+		}
+	}
+
+	rvalue := C.calloc(1, 16)
+	defer C.free(rvalue)
+	C.callcall(libffi.call, f.cif, f.fn, rvalue, (*unsafe.Pointer)(mem))
+	runtime.KeepAlive(f)
+	return result(rvalue, f.ret), nil
+}
+
+// setArg stores a into the slot at p as type t.
+// If a C string had to be made, it is returned so the caller can free it.
+func setArg(p unsafe.Pointer, t CType, a interface{}) (unsafe.Pointer, error) {
+	v := reflect.ValueOf(a)
+	switch t {
+	case CFloat, CDouble:
+		var x float64
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			x = v.Float()
+		default:
+			return nil, fmt.Errorf("%T is not a float", a)
+		}
+		if t == CFloat {
+			*(*float32)(p) = float32(x)
+		} else {
+			*(*float64)(p) = x
+		}
+		return nil, nil
+	case CPointer:
+		switch x := a.(type) {
+		case nil:
+			*(*unsafe.Pointer)(p) = nil
+		case unsafe.Pointer:
+			*(*unsafe.Pointer)(p) = x
+		case uintptr:
+			*(*uintptr)(p) = x
+		default:
+			return nil, fmt.Errorf("%T is not a pointer", a)
+		}
+		return nil, nil
+	case CString:
+		switch x := a.(type) {
+		case nil:
+			*(*unsafe.Pointer)(p) = nil
+			return nil, nil
+		case string:
+			cs := unsafe.Pointer(C.CString(x))
+			*(*unsafe.Pointer)(p) = cs
+			return cs, nil
+		}
+		return nil, fmt.Errorf("%T is not a string", a)
+	}
+
+	// integers
+	var x uint64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x = uint64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x = v.Uint()
+	default:
+		return nil, fmt.Errorf("%T is not an integer", a)
+	}
+	switch intSize(t) {
+	case 1:
+		*(*uint8)(p) = uint8(x)
+	case 2:
+		*(*uint16)(p) = uint16(x)
+	case 4:
+		*(*uint32)(p) = uint32(x)
+	default:
+		*(*uint64)(p) = x
+	}
+	return nil, nil
+}
+
+// intSize returns the size of the integer type t.
+func intSize(t CType) uintptr {
+	switch t {
+	case CInt8, CUint8:
+		return 1
+	case CInt16, CUint16:
+		return 2
+	case CInt32, CUint32, CInt, CUint:
+		return 4
+	case CLong, CUlong:
+		return unsafe.Sizeof(C.long(0))
+	case CSize:
+		return unsafe.Sizeof(C.size_t(0))
+	}
+	return 8
+}
+
+// result converts the return value libffi left at rvalue.
+// libffi widens integer results smaller than a long to a whole long.
+func result(rvalue unsafe.Pointer, t CType) interface{} {
+	switch t {
+	case CVoid:
+		return nil
+	case CFloat:
+		return float64(*(*float32)(rvalue))
+	case CDouble:
+		return *(*float64)(rvalue)
+	case CPointer:
+		return *(*unsafe.Pointer)(rvalue)
+	case CString:
+		return C.GoString(*(**C.char)(rvalue))
+	}
+	size := intSize(t)
+	var x uint64
+	switch {
+	case size < unsafe.Sizeof(C.ulong(0)):
+		x = uint64(*(*C.ulong)(rvalue))
+	case size == 4:
+		x = uint64(*(*uint32)(rvalue))
+	default:
+		x = *(*uint64)(rvalue)
+	}
+	bits := size * 8
+	switch t {
+	case CInt8, CInt16, CInt32, CInt64, CInt, CLong:
+		return int64(x << (64 - bits)) >> (64 - bits)		// sign-extend
+	}
+	return x & (^uint64(0) >> (64 - bits))
+}
@@ -0,0 +1,21 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// Func is a C function that can be called directly from Go.
+// Funcs need libffi, which this package does not look for on Windows; use syscall.SyscallN instead.
+type Func struct{}
+
+// NewFunc returns ErrUnsupported on Windows.
+func NewFunc(fn unsafe.Pointer, ret CType, args ...CType) (*Func, error) {
+	return nil, ErrUnsupported
+}
+
+// Call returns ErrUnsupported on Windows.
+func (f *Func) Call(args ...interface{}) (interface{}, error) {
+	return nil, ErrUnsupported
+}
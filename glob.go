@@ -0,0 +1,29 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"path"
+)
+
+// Glob lists every symbol m exports whose name matches pattern, in the order ExportedSymbols gives them, for plugin systems that find what a library offers by name rather than from a fixed list:
+// 	codecs, err := m.Glob("vpx_codec_*_algo")
+// The pattern syntax is that of path.Match: * matches any run of characters, ? matches one, and [] matches a class; an invalid pattern returns path.ErrBadPattern.
+// A symbol with more than one version is listed once for each.
+// Like ExportedSymbols, this only works on ELF systems.
+func (m Module) Glob(pattern string) ([]ExportedSymbol, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	syms, err := m.ExportedSymbols()
+	if err != nil {
+		return nil, err
+	}
+	var matched []ExportedSymbol
+	for _, s := range syms {
+		if ok, _ := path.Match(pattern, s.Name); ok {
+			matched = append(matched, s)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,57 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"sync"
+)
+
+// Group is a set of Modules that are closed together, in the reverse of the order they were added.
+// Add libraries in the order they depend on each other (a library before the ones that use it) and Close will never unload a library while one loaded after it may still refer to it.
+// The zero Group is empty and ready to use; a Group is safe for concurrent use.
+type Group struct {
+	lock	sync.Mutex
+	mods	[]Module
+}
+
+// Add adds modules to g, in order; g now owns them.
+func (g *Group) Add(modules ...Module) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.mods = append(g.mods, modules...)
+}
+
+// Open opens the named library and adds it to g.
+func (g *Group) Open(name string, mode Mode) (Module, error) {
+	m, err := Open(name, mode)
+	if err != nil {
+		return 0, err
+	}
+	g.Add(m)
+	return m, nil
+}
+
+// Modules returns the Modules in g, in the order they were added.
+func (g *Group) Modules() []Module {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return append([]Module(nil), g.mods...)
+}
+
+// Close closes every Module in g, most recently added first, and leaves g empty.
+// A Module that fails to close doesn't stop the rest from being closed; the returned error lists every failure.
+func (g *Group) Close() error {
+	var errs []error
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for i := len(g.mods) - 1; i >= 0; i-- {
+		if err := g.mods[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	g.mods = nil
+	return errors.Join(errs...)
+}
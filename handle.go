@@ -0,0 +1,24 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// Handle returns the handle the system uses for m: what dlopen() returned, or the HMODULE on Windows.
+// Use it to pass m to C code that wants the handle itself.
+func (m Module) Handle() unsafe.Pointer {
+	return unsafe.Pointer(m)
+}
+
+// FromHandle returns the Module for a handle returned by dlopen() (or LoadLibrary() on Windows) in other C code, so this package's methods can be used on it.
+// The package doesn't count it as opened: Close releases the reference the C code took, and it is up to you to make sure that happens only once.
+func FromHandle(h unsafe.Pointer) Module {
+	m := Module(uintptr(h))
+
+	dllock.Lock()
+	defer dllock.Unlock()
+	delete(closedModules, m)		// the C code may have opened the same library again after we last closed it
+	return m
+}
@@ -0,0 +1,30 @@
+// 14 october 2026
+
+package dl
+
+// Lmid identifies a link-map namespace: a separate set of loaded objects with its own symbol scope (see dlmopen()).
+// Everything is in the base namespace unless loaded otherwise.
+type Lmid int64
+
+// These are the special values of Lmid that can be passed to OpenNamespace.
+const (
+	LMBase Lmid = 0		// the base namespace (LM_ID_BASE)
+	LMNew Lmid = -1		// a new namespace (LM_ID_NEWLM)
+)
+
+// ModuleInfo is what the dynamic linker knows about a Module, as returned by Module.Info.
+type ModuleInfo struct {
+	// Path is the name the dynamic linker has for the object, which is normally its full path.
+	// It is empty for the main program.
+	Path		string
+
+	// Origin is the directory containing the object; it's the same directory that $ORIGIN refers to in the object's run path.
+	// Use it to find data files shipped alongside a plugin.
+	Origin	string
+
+	// Base is the difference between addresses in the file and addresses in memory; for shared objects, this is the address the object was loaded at.
+	Base		uintptr
+
+	// Lmid is the namespace the object lives in.
+	Lmid	Lmid
+}
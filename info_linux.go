@@ -0,0 +1,124 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"os"
+	"path/filepath"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <link.h>
+// #include <limits.h>
+// #include <stdlib.h>
+// /* musl only has RTLD_DI_LINKMAP; the rest are made up from it instead */
+// /* (glibc's RTLD_DI_ constants are an enum, so they can't be tested for directly) */
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// #ifdef __GLIBC__
+// #define haveOrigin 1
+// static int origin(void *handle, char *buf, char **err)
+// {
+// 	int r;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	r = dlinfo(handle, RTLD_DI_ORIGIN, buf);
+// 	if (r != 0)
+// 		*err = dlerrordup();
+// 	return r;
+// }
+// static int lmid(void *handle, long *id, char **err)
+// {
+// 	Lmid_t l;
+// 	int r;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	r = dlinfo(handle, RTLD_DI_LMID, &l);
+// 	if (r != 0)
+// 		*err = dlerrordup();
+// 	*id = l;
+// 	return r;
+// }
+// #else
+// #define haveOrigin 0
+// static int origin(void *handle, char *buf, char **err) { *err = NULL; return -1; }
+// static int lmid(void *handle, long *id, char **err) { *err = NULL; *id = 0; return 0; }
+// #endif
+import "C"
+
+// Info returns what the dynamic linker knows about m, using dlinfo().
+// With C libraries that don't provide everything (such as musl), Origin is worked out from Path and Lmid is always 0; they have no other namespaces anyway.
+func (m Module) Info() (*ModuleInfo, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.Info)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if m.pseudo() {
+		return nil, errPseudoPath		// before any dlinfo(), which crashes on them
+	}
+	lm, err := m.linkmap()
+	if err != nil {
+		return nil, err
+	}
+	info := &ModuleInfo{
+		Path:	C.GoString(lm.l_name),
+		Base:	uintptr(lm.l_addr),
+	}
+
+	if C.haveOrigin != 0 {
+		buf := (*C.char)(C.malloc(C.PATH_MAX + 1))
+		defer C.free(unsafe.Pointer(buf))
+		var e *C.char
+		if C.origin(unsafe.Pointer(m), buf, &e) != 0 {
+			return nil, takeError(e)
+		}
+		info.Origin = C.GoString(buf)
+	} else if info.Path != "" {
+		info.Origin = filepath.Dir(info.Path)
+	} else if exe, err := os.Executable(); err == nil {
+		info.Origin = filepath.Dir(exe)
+	}
+
+	info.Lmid, err = m.lmid()
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Lmid returns the namespace m lives in; use it to open more libraries next to m with OpenNamespace, or to check that a library opened into its own namespace really is in one.
+// This is the same as the Lmid field of Info, without working out the rest.
+// As there, with C libraries that don't have namespaces (such as musl), it is always LMBase.
+func (m Module) Lmid() (Lmid, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.Lmid)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if err := m.closedError("info", ""); err != nil {
+		return 0, err
+	}
+	return m.lmid()
+}
+
+// lmid does the work of Lmid.
+// As with linkmap, pseudo-Modules are rejected, since dlinfo() crashes on them.
+// The caller must hold dllock.
+func (m Module) lmid() (Lmid, error) {
+	var id C.long
+	var e *C.char
+
+	if m.pseudo() {
+		return 0, errPseudoPath
+	}
+	if C.lmid(unsafe.Pointer(m), &id, &e) != 0 {
+		return 0, takeError(e)
+	}
+	return Lmid(id), nil
+}
@@ -0,0 +1,17 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// Info returns what the dynamic linker knows about m.
+// It needs dlinfo(), so on this system it returns ErrUnsupported.
+func (m Module) Info() (*ModuleInfo, error) {
+	return nil, ErrUnsupported
+}
+
+// Lmid returns the namespace m lives in.
+// Only glibc has namespaces, so on this system it returns ErrUnsupported.
+func (m Module) Lmid() (Lmid, error) {
+	return 0, ErrUnsupported
+}
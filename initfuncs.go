@@ -0,0 +1,30 @@
+// 14 october 2026
+
+package dl
+
+// InitFunction is one of the functions the dynamic linker runs when it loads an object, as returned by Module.InitFunctions.
+type InitFunction struct {
+	Object	string		// the file of the object the function belongs to
+	Kind		string		// where the function is listed: "DT_INIT", ".ctors", or ".init_array"
+	Name	string		// the function's name, if the object's symbol tables have one for it; constructors are usually static, so this needs an unstripped object
+	Offset	uintptr		// the function's address relative to the object's BaseAddress, for symbolizers
+	Addr		uintptr		// the function's address in memory
+}
+
+// InitFunctions lists the constructors m's object has, in the order the dynamic linker runs them: its DT_INIT function first (which on old toolchains runs the .ctors entries itself, listed after it), then its .init_array entries.
+// These all ran before Open returned; the list is for working out which one is to blame when a library misbehaves while loading, for instance by hanging (see also WithConstructorReport).
+// The addresses are read from the loaded object, so they are the relocated ones.
+// This only works where Info does, and returns ErrUnsupported elsewhere.
+func (m Module) InitFunctions() ([]InitFunction, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.InitFunctions)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()		// so m can't be unloaded while its memory is read
+
+	o, err := m.object()
+	if err != nil {
+		return nil, err
+	}
+	return initFunctions(o.path, o.bias)
+}
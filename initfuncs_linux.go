@@ -0,0 +1,76 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"fmt"
+	"unsafe"
+)
+
+// initFunctions reads the constructors of the object loaded from path with the given bias.
+// The caller must hold dllock, so the object stays loaded.
+func initFunctions(path string, bias uintptr) ([]InitFunction, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("dl: could not read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	names := make(map[uint64]string)
+	for _, syms := range [][]elf.Symbol{symbolsOf(f.Symbols), symbolsOf(f.DynamicSymbols)} {
+		for _, s := range syms {
+			if elf.ST_TYPE(s.Info) == elf.STT_FUNC && s.Value != 0 && names[s.Value] == "" {
+				names[s.Value] = s.Name
+			}
+		}
+	}
+	var fns []InitFunction
+	add := func(kind string, addr uintptr) {
+		if addr == 0 || addr == ^uintptr(0) {		// .ctors is bracketed by -1 and 0
+			return
+		}
+		fns = append(fns, InitFunction{
+			Object:	path,
+			Kind:	kind,
+			Name:	names[uint64(addr - bias)],
+			Offset:	addr - bias,
+			Addr:	addr,
+		})
+	}
+	// table reads n pointers from the object's memory at the file address vaddr
+	table := func(vaddr uint64, size uint64) []uintptr {
+		n := size / uint64(unsafe.Sizeof(uintptr(0)))
+		if vaddr == 0 || n == 0 {
+			return nil
+		}
+		return unsafe.Slice((*uintptr)(unsafe.Pointer(bias + uintptr(vaddr))), n)
+	}
+
+	if v, err := f.DynValue(elf.DT_INIT); err == nil && len(v) != 0 && v[0] != 0 {
+		add("DT_INIT", bias + uintptr(v[0]))
+	}
+	if s := f.Section(".ctors"); s != nil {
+		t := table(s.Addr, s.Size)
+		for i := len(t) - 1; i >= 0; i-- {		// _init runs them last to first
+			add(".ctors", t[i])
+		}
+	}
+	addr, err1 := f.DynValue(elf.DT_INIT_ARRAY)
+	size, err2 := f.DynValue(elf.DT_INIT_ARRAYSZ)
+	if err1 == nil && err2 == nil && len(addr) != 0 && len(size) != 0 {
+		for _, p := range table(addr[0], size[0]) {
+			add(".init_array", p)
+		}
+	}
+	return fns, nil
+}
+
+// symbolsOf returns what get returns, or nothing if it fails; a stripped object has no symbol table, which isn't an error here.
+func symbolsOf(get func() ([]elf.Symbol, error)) []elf.Symbol {
+	syms, err := get()
+	if err != nil {
+		return nil
+	}
+	return syms
+}
@@ -0,0 +1,9 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+func initFunctions(path string, bias uintptr) ([]InitFunction, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,283 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Inspection is what Inspect found out about a file.
+type Inspection struct {
+	Path		string
+	Format	string		// "ELF", "Mach-O", or "PE"
+	Arch		string		// the architecture the file was built for, named the way GOARCH would where possible
+	Bits		int			// 32 or 64
+
+	// SOName is the name the file gives itself, which other objects record when they are linked against it: the DT_SONAME on ELF, the install name on Mach-O, and the name in the export directory on PE.
+	// It is empty if the file has none.
+	SOName	string
+
+	// Dependencies are the libraries the file was linked against, as recorded in it (so not paths, on ELF and PE).
+	Dependencies	[]string
+
+	// Exports are the names of the symbols the file makes available to others, in the order the file lists them; names with several versions are listed once.
+	// Mach-O's leading underscore is removed, as Symbol doesn't need it.
+	Exports	[]string
+
+	// Loadable is nil if this process could load the file, or the *FormatError ValidateFile gives otherwise.
+	Loadable	error
+}
+
+// Inspect reads the shared library at path and reports what it is, what it needs, and what it exports, without loading it.
+// Loading a library runs its constructors, so this is the way to vet a library before trusting it; nothing in the file is executed.
+// Any of the three formats can be read on any system, so a Windows DLL can be inspected on Linux (where it is not Loadable, of course).
+// For a universal Mach-O binary, the slice for this process's architecture is reported if there is one, and the first slice otherwise.
+func Inspect(path string) (*Inspection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var magic [4]byte
+	if _, err := io.ReadFull(f, magic[:]); err != nil {
+		return nil, fmt.Errorf("dl: %s is too short to be a library", path)
+	}
+
+	in := &Inspection{
+		Path:	path,
+	}
+	switch {
+	case bytes.Equal(magic[:], []byte(elf.ELFMAG)):
+		err = in.elf(f)
+	case magic[0] == 'M' && magic[1] == 'Z':
+		err = in.pe(f)
+	default:
+		switch binary.BigEndian.Uint32(magic[:]) {
+		case macho.Magic32, macho.Magic64, macho.MagicFat, 0xcefaedfe, 0xcffaedfe:		// the last two are Magic32 and Magic64 in little-endian files
+			err = in.macho(f)
+		default:
+			return nil, &FormatError{
+				Path:	path,
+				Reason:	"not an ELF, Mach-O, or PE file",
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dl: could not read %s: %w", path, err)
+	}
+	in.Loadable = validateFile(path)
+	return in, nil
+}
+
+// elfArchs names ELF machine types the way GOARCH would.
+var elfArchs = map[elf.Machine]string{
+	elf.EM_386:		"386",
+	elf.EM_X86_64:		"amd64",
+	elf.EM_ARM:		"arm",
+	elf.EM_AARCH64:	"arm64",
+	elf.EM_LOONGARCH:	"loong64",
+	elf.EM_MIPS:		"mips",
+	elf.EM_PPC:		"ppc",
+	elf.EM_PPC64:		"ppc64",
+	elf.EM_RISCV:		"riscv",
+	elf.EM_S390:		"s390x",
+}
+
+func (in *Inspection) elf(r io.ReaderAt) error {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return err
+	}
+	in.Format = "ELF"
+	in.Arch = elfArchs[f.Machine]
+	if in.Arch == "" {
+		in.Arch = f.Machine.String()
+	}
+	in.Bits = 32
+	if f.Class == elf.ELFCLASS64 {
+		in.Bits = 64
+	}
+	if sonames, err := f.DynString(elf.DT_SONAME); err == nil && len(sonames) != 0 {
+		in.SOName = sonames[0]
+	}
+	in.Dependencies, _ = f.ImportedLibraries()
+	syms, _ := f.DynamicSymbols()
+	seen := make(map[string]bool)
+	for _, s := range syms {
+		if s.Section == elf.SHN_ABS && s.Value == 0 {
+			continue		// names a symbol version, not a symbol
+		}
+		if isExported(s) && !seen[s.Name] {
+			seen[s.Name] = true
+			in.Exports = append(in.Exports, s.Name)
+		}
+	}
+	return nil
+}
+
+// machoArchs names Mach-O CPU types the way GOARCH would.
+var machoArchs = map[macho.Cpu]string{
+	macho.Cpu386:	"386",
+	macho.CpuAmd64:	"amd64",
+	macho.CpuArm:	"arm",
+	macho.CpuArm64:	"arm64",
+	macho.CpuPpc:	"ppc",
+	macho.CpuPpc64:	"ppc64",
+}
+
+const machoIDDylib macho.LoadCmd = 0xd		// LC_ID_DYLIB, which debug/macho doesn't parse
+
+func (in *Inspection) macho(r io.ReaderAt) error {
+	var f *macho.File
+
+	if fat, err := macho.NewFatFile(r); err == nil {
+		f = fat.Arches[0].File
+		for _, a := range fat.Arches {
+			if machoArchs[a.Cpu] == runtime.GOARCH {
+				f = a.File
+				break
+			}
+		}
+	} else {
+		f, err = macho.NewFile(r)
+		if err != nil {
+			return err
+		}
+	}
+	in.Format = "Mach-O"
+	in.Arch = machoArchs[f.Cpu]
+	if in.Arch == "" {
+		in.Arch = f.Cpu.String()
+	}
+	in.Bits = 32
+	if f.Magic == macho.Magic64 {
+		in.Bits = 64
+	}
+	for _, l := range f.Loads {
+		raw := l.Raw()
+		if len(raw) < 12 || macho.LoadCmd(f.ByteOrder.Uint32(raw)) != machoIDDylib {
+			continue
+		}
+		// struct dylib_command: cmd, cmdsize, then the offset of the name from the start of the command
+		if off := f.ByteOrder.Uint32(raw[8:]); off < uint32(len(raw)) {
+			name := raw[off:]
+			if i := bytes.IndexByte(name, 0); i >= 0 {
+				name = name[:i]
+			}
+			in.SOName = string(name)
+		}
+	}
+	in.Dependencies, _ = f.ImportedLibraries()
+	if f.Symtab != nil {
+		seen := make(map[string]bool)
+		for _, s := range f.Symtab.Syms {
+			// N_EXT and defined in a section (N_SECT); N_STAB entries are debugging information
+			if s.Type & 0xe0 != 0 || s.Type & 0x01 == 0 || s.Type & 0x0e != 0x0e {
+				continue
+			}
+			name := s.Name
+			if len(name) != 0 && name[0] == '_' {
+				name = name[1:]
+			}
+			if !seen[name] {
+				seen[name] = true
+				in.Exports = append(in.Exports, name)
+			}
+		}
+	}
+	return nil
+}
+
+// peArchs names PE machine types the way GOARCH would.
+var peArchs = map[uint16]string{
+	pe.IMAGE_FILE_MACHINE_I386:	"386",
+	pe.IMAGE_FILE_MACHINE_AMD64:	"amd64",
+	pe.IMAGE_FILE_MACHINE_ARMNT:	"arm",
+	pe.IMAGE_FILE_MACHINE_ARM64:	"arm64",
+}
+
+func (in *Inspection) pe(r io.ReaderAt) error {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		return err
+	}
+	in.Format = "PE"
+	in.Arch = peArchs[f.Machine]
+	if in.Arch == "" {
+		in.Arch = fmt.Sprintf("machine %#x", f.Machine)
+	}
+	var exports pe.DataDirectory
+	switch h := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		in.Bits = 32
+		if h.NumberOfRvaAndSizes > pe.IMAGE_DIRECTORY_ENTRY_EXPORT {
+			exports = h.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_EXPORT]
+		}
+	case *pe.OptionalHeader64:
+		in.Bits = 64
+		if h.NumberOfRvaAndSizes > pe.IMAGE_DIRECTORY_ENTRY_EXPORT {
+			exports = h.DataDirectory[pe.IMAGE_DIRECTORY_ENTRY_EXPORT]
+		}
+	}
+	// debug/pe's ImportedLibraries always returns nothing, but ImportedSymbols gives each import as "symbol:dll"
+	syms, _ := f.ImportedSymbols()
+	seen := make(map[string]bool)
+	for _, s := range syms {
+		if i := strings.LastIndexByte(s, ':'); i >= 0 && !seen[s[i + 1:]] {
+			seen[s[i + 1:]] = true
+			in.Dependencies = append(in.Dependencies, s[i + 1:])
+		}
+	}
+	if exports.VirtualAddress != 0 {
+		in.SOName, in.Exports = peExports(f, exports.VirtualAddress)
+	}
+	return nil
+}
+
+// peExports reads the DLL name and the names of the exports from the export directory at rva.
+// Exports by ordinal only have no name, and are left out.
+func peExports(f *pe.File, rva uint32) (string, []string) {
+	// read returns the data at the given RVA, to the end of its section
+	read := func(rva uint32) []byte {
+		for _, s := range f.Sections {
+			if rva >= s.VirtualAddress && rva < s.VirtualAddress + s.VirtualSize {
+				data, err := s.Data()
+				if err != nil || rva - s.VirtualAddress >= uint32(len(data)) {
+					return nil
+				}
+				return data[rva - s.VirtualAddress:]
+			}
+		}
+		return nil
+	}
+	str := func(rva uint32) string {
+		b := read(rva)
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+		return string(b)
+	}
+
+	// IMAGE_EXPORT_DIRECTORY: the name's RVA is at 12, the number of names at 24, and the RVA of the table of name RVAs at 32
+	dir := read(rva)
+	if len(dir) < 40 {
+		return "", nil
+	}
+	name := str(binary.LittleEndian.Uint32(dir[12:]))
+	n := binary.LittleEndian.Uint32(dir[24:])
+	table := read(binary.LittleEndian.Uint32(dir[32:]))
+	var names []string
+	for i := uint32(0); i < n && int(i * 4 + 4) <= len(table); i++ {
+		names = append(names, str(binary.LittleEndian.Uint32(table[i * 4:])))
+	}
+	return name, names
+}
@@ -0,0 +1,148 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestInspectELF(t *testing.T) {
+	dep := dltest.Build(t, "int depvalue(void) { return 7; }\n")
+	lib := dltest.Build(t, `
+extern int depvalue(void);
+int value(void) { return depvalue() + 1; }
+int value2(void) { return 2; }
+static int hidden(void) { return 3; }
+int usehidden(void) { return hidden(); }
+`, "-Wl,-soname,libinspecttest.so.1", dep)
+
+	in, err := dl.Inspect(lib)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if in.Format != "ELF" || in.Arch != runtime.GOARCH {
+		t.Errorf("Format, Arch = %q, %q; want ELF, %q", in.Format, in.Arch, runtime.GOARCH)
+	}
+	if in.SOName != "libinspecttest.so.1" {
+		t.Errorf("SOName = %q; want libinspecttest.so.1", in.SOName)
+	}
+	if !slices.Contains(in.Dependencies, dep) {
+		t.Errorf("Dependencies = %q; want them to include %q", in.Dependencies, dep)
+	}
+	for _, name := range []string{"value", "value2", "usehidden"} {
+		if !slices.Contains(in.Exports, name) {
+			t.Errorf("Exports = %q; want them to include %s", in.Exports, name)
+		}
+	}
+	for _, name := range []string{"hidden", "depvalue"} {
+		if slices.Contains(in.Exports, name) {
+			t.Errorf("Exports = %q; want them not to include %s, which the library doesn't define", in.Exports, name)
+		}
+	}
+	if in.Loadable != nil {
+		t.Errorf("Loadable = %v; want nil for a library built for this process", in.Loadable)
+	}
+	if loaded, err := dl.IsLoaded(lib); err == nil && loaded {
+		t.Errorf("Inspect loaded the library")
+	}
+}
+
+func TestInspectNotALibrary(t *testing.T) {
+	text := filepath.Join(t.TempDir(), "text")
+	if err := os.WriteFile(text, []byte("this is not a library, just some text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dl.Inspect(text); !errors.Is(err, dl.ErrBadFormat) {
+		t.Errorf("Inspect of a text file error = %v; want ErrBadFormat", err)
+	}
+}
+
+// goTestdata returns the path of a file in the Go distribution's debug/name/testdata, decoding it from base64 if need be, or skips the test if there is no Go distribution to read.
+func goTestdata(t *testing.T, name string, file string) string {
+	t.Helper()
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		t.Skipf("no Go distribution to take %s from: %v", file, err)
+	}
+	path := filepath.Join(strings.TrimSpace(string(out)), "src", "debug", name, "testdata", file)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Skipf("no %s in the Go distribution: %v", file, err)
+	}
+	if strings.HasSuffix(file, ".base64") {
+		data, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		path = filepath.Join(t.TempDir(), strings.TrimSuffix(file, ".base64"))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestInspectPE(t *testing.T) {
+	in, err := dl.Inspect(goTestdata(t, "pe", "gcc-amd64-mingw-exec"))
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if in.Format != "PE" || in.Arch != "amd64" || in.Bits != 64 {
+		t.Errorf("Format, Arch, Bits = %q, %q, %d; want PE, amd64, 64", in.Format, in.Arch, in.Bits)
+	}
+	if !slices.ContainsFunc(in.Dependencies, func(d string) bool {
+		return strings.EqualFold(d, "msvcrt.dll")
+	}) {
+		t.Errorf("Dependencies = %q; want them to include msvcrt.dll", in.Dependencies)
+	}
+	if in.Loadable == nil {
+		t.Errorf("Loadable = nil for a Windows executable on Linux")
+	}
+}
+
+func TestInspectMachO(t *testing.T) {
+	in, err := dl.Inspect(goTestdata(t, "macho", "gcc-amd64-darwin-exec.base64"))
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if in.Format != "Mach-O" || in.Arch != "amd64" || in.Bits != 64 {
+		t.Errorf("Format, Arch, Bits = %q, %q, %d; want Mach-O, amd64, 64", in.Format, in.Arch, in.Bits)
+	}
+	if !slices.ContainsFunc(in.Dependencies, func(d string) bool {
+		return strings.HasPrefix(filepath.Base(d), "libSystem")
+	}) {
+		t.Errorf("Dependencies = %q; want them to include libSystem", in.Dependencies)
+	}
+	if !slices.Contains(in.Exports, "main") {
+		t.Errorf("Exports = %q; want them to include main, without its underscore", in.Exports)
+	}
+	if in.Loadable == nil {
+		t.Errorf("Loadable = nil for an OS X executable on Linux")
+	}
+
+	fat, err := dl.Inspect(goTestdata(t, "macho", "fat-gcc-386-amd64-darwin-exec.base64"))
+	if err != nil {
+		t.Fatalf("Inspect of a universal binary: %v", err)
+	}
+	want := "386"		// the first slice
+	if runtime.GOARCH == "amd64" {
+		want = "amd64"		// this process's
+	}
+	if fat.Arch != want {
+		t.Errorf("universal binary Arch = %q; want %q", fat.Arch, want)
+	}
+}
@@ -0,0 +1,45 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"time"
+)
+
+// Instrumentation receives a count and timing of every open, symbol lookup, and close, for wiring up to a metrics system such as Prometheus or OpenTelemetry.
+// library is the name the library was opened with (empty for OpenSelf, and for pseudo-Modules and Modules this package didn't open), so per-library latencies and failure counts can be kept; err is what the operation returned.
+// The same rules apply as for a TraceFunc: the methods are called with the package's lock held, so they must not call anything in this package, they should be quick, and Symbol can be called by more than one goroutine at once.
+type Instrumentation interface {
+	Open(library string, d time.Duration, err error)
+	Symbol(library string, symbol string, d time.Duration, err error)
+	Close(library string, d time.Duration, err error)
+}
+
+// instrumentation is guarded by dllock.
+var instrumentation Instrumentation
+
+// SetInstrumentation arranges for i to be told about every open (by any of the Open functions), Symbol lookup, and Close, successful or not.
+// Pass nil to stop.
+// This works alongside SetTraceFunc; both are called.
+// For expvar, PublishExpvars already keeps totals and failure counts by library.
+func SetInstrumentation(i Instrumentation) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	instrumentation = i
+}
+
+// instrument reports an operation that took d to the Instrumentation, if there is one.
+// The caller must hold dllock, for reading at least.
+func instrument(op string, library string, symbol string, d time.Duration, err error) {
+	if instrumentation == nil {
+		return
+	}
+	switch op {
+	case "open":
+		instrumentation.Open(library, d, err)
+	case "symbol":
+		instrumentation.Symbol(library, symbol, d, err)
+	case "close":
+		instrumentation.Close(library, d, err)
+	}
+}
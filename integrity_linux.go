@@ -0,0 +1,125 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"os"
+	"io"
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+)
+
+// VerifyIntegrity compares the executable segments of m as they are in memory against the same segments in the file m was loaded from.
+// It returns false if they differ, which means someone (a debugger, an instrumentation tool, or an attacker) has modified the code since it was loaded.
+// The memory is read through /proc/self/mem; if that cannot be opened, ErrUnsupported is returned.
+//
+// Caveat: the dynamic linker is itself allowed to modify executable segments when it applies text relocations.
+// These are skipped if they can be found in the file's section headers, so a library that uses text relocations and has had its section headers stripped will be reported as modified.
+// (The GOT and PLT slots that normally receive relocations are not in executable segments, so they do not affect the result.)
+func (m Module) VerifyIntegrity() (bool, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.VerifyIntegrity)
+	}
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return false, err
+	}
+	mem, err := os.Open("/proc/self/mem")
+	if err != nil {
+		return false, ErrUnsupported
+	}
+	defer mem.Close()
+	f, err := elf.Open(o.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	relocs, err := textRelocations(f)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range f.Progs {
+		if p.Type != elf.PT_LOAD || p.Flags & elf.PF_X == 0 {
+			continue
+		}
+		want := make([]byte, p.Filesz)
+		if _, err := io.ReadFull(p.Open(), want); err != nil {
+			return false, err
+		}
+		got := make([]byte, p.Filesz)
+		if _, err := mem.ReadAt(got, int64(o.bias + uintptr(p.Vaddr))); err != nil {
+			return false, err
+		}
+		// blank out anything the dynamic linker was allowed to change
+		for _, r := range relocs {
+			if r.off < p.Vaddr || r.off + r.size > p.Vaddr + p.Filesz {
+				continue
+			}
+			start := r.off - p.Vaddr
+			copy(got[start:start + r.size], want[start:start + r.size])
+		}
+		if !bytes.Equal(want, got) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// reloc is the range of addresses (in the file) written to by a relocation.
+type reloc struct {
+	off		uint64
+	size	uint64
+}
+
+// textRelocations returns the targets of every dynamic relocation in f.
+// The caller is expected to ignore the ones that do not fall in an executable segment.
+func textRelocations(f *elf.File) ([]reloc, error) {
+	var relocs []reloc
+
+	for _, s := range f.Sections {
+		if s.Type != elf.SHT_RELA && s.Type != elf.SHT_REL {
+			continue
+		}
+		// only relocations against the dynamic symbol table are applied at load time
+		if int(s.Link) >= len(f.Sections) || f.Sections[s.Link].Type != elf.SHT_DYNSYM {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil {
+			return nil, err
+		}
+		r := bytes.NewReader(data)
+		for r.Len() > 0 {
+			var off uint64
+			var size uint64
+
+			switch {
+			case f.Class == elf.ELFCLASS64 && s.Type == elf.SHT_RELA:
+				var rel elf.Rela64
+				err = binary.Read(r, f.ByteOrder, &rel)
+				off, size = rel.Off, 8
+			case f.Class == elf.ELFCLASS64:
+				var rel elf.Rel64
+				err = binary.Read(r, f.ByteOrder, &rel)
+				off, size = rel.Off, 8
+			case s.Type == elf.SHT_RELA:
+				var rel elf.Rela32
+				err = binary.Read(r, f.ByteOrder, &rel)
+				off, size = uint64(rel.Off), 4
+			default:
+				var rel elf.Rel32
+				err = binary.Read(r, f.ByteOrder, &rel)
+				off, size = uint64(rel.Off), 4
+			}
+			if err != nil {
+				return nil, err
+			}
+			relocs = append(relocs, reloc{off, size})
+		}
+	}
+	return relocs, nil
+}
@@ -0,0 +1,11 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// VerifyIntegrity compares the executable segments of m as they are in memory against the same segments in the file m was loaded from.
+// It is only implemented on Linux; elsewhere it returns ErrUnsupported.
+func (m Module) VerifyIntegrity() (bool, error) {
+	return false, ErrUnsupported
+}
@@ -0,0 +1,70 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+const integritySource = `
+#include <stdint.h>
+#include <sys/mman.h>
+#include <unistd.h>
+int answer(void) { return 42; }
+int patch(void)
+{
+	long page = sysconf(_SC_PAGESIZE);
+	unsigned char *p = (unsigned char *) answer;
+	void *start = (void *) ((uintptr_t) p & ~(uintptr_t) (page - 1));
+
+	if (mprotect(start, 2 * page, PROT_READ | PROT_WRITE | PROT_EXEC) != 0)
+		return -1;
+	p[0] ^= 0xFF;
+	return 0;
+}
+`
+
+func TestVerifyIntegrity(t *testing.T) {
+	lib := dltest.Build(t, integritySource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	defer m.Close()
+
+	ok, err := m.VerifyIntegrity()
+	if errors.Is(err, dl.ErrUnsupported) {
+		t.Skipf("VerifyIntegrity: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("VerifyIntegrity: %v", err)
+	}
+	if !ok {
+		t.Errorf("VerifyIntegrity of an unmodified library = false; want true")
+	}
+
+	p, err := m.StrictSymbol("patch")
+	if err != nil {
+		t.Fatalf("StrictSymbol(patch): %v", err)
+	}
+	patch, err := dl.NewFunc(p, dl.CInt)
+	if err != nil {
+		t.Skipf("NewFunc: %v", err)
+	}
+	if r, err := patch.Call(); err != nil || r != int64(0) {
+		t.Skipf("could not patch the library (%v, %v)", r, err)
+	}
+	ok, err = m.VerifyIntegrity()
+	if err != nil {
+		t.Fatalf("VerifyIntegrity after patching: %v", err)
+	}
+	if ok {
+		t.Errorf("VerifyIntegrity of a patched library = true; want false")
+	}
+}
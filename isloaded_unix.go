@@ -0,0 +1,73 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"path/filepath"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// static int probe(const char *name)
+// {
+// #ifdef RTLD_NOLOAD
+// 	void *h;
+//
+// 	h = dlopen(name, RTLD_LAZY | RTLD_NOLOAD);
+// 	if (h == NULL)
+// 		return 0;
+// 	dlclose(h);		/* drop the reference dlopen() took */
+// 	return 1;
+// #else
+// 	return -1;
+// #endif
+// }
+import "C"
+
+// IsLoaded reports whether the named library is already loaded into the process, without loading it and without keeping a reference to it.
+// Use it to decide whether to use a library something else has loaded or to load a bundled copy of your own.
+// The name is looked up the same way Open would look it up.
+//
+// This is done with RTLD_NOLOAD, which every common system has.
+// Where it isn't available, the list of loaded objects is searched for one whose file has the same name instead; this can't see what a bare name would have resolved to, so compare by file name (for instance, "libssl.so.3") rather than by path.
+// If neither works, IsLoaded returns ErrUnsupported.
+func IsLoaded(name string) (bool, error) {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	names := []string{name}
+	if alt := altName(name); alt != "" {
+		names = append(names, alt)
+	}
+	for _, name := range names {
+		cname := C.CString(name)
+		r := C.probe(cname)
+		C.free(unsafe.Pointer(cname))
+		switch r {
+		case 1:
+			return true, nil
+		case -1:
+			return isLoadedByName(name)
+		}
+	}
+	return false, nil
+}
+
+// isLoadedByName is IsLoaded without RTLD_NOLOAD.
+// The caller must hold dllock.
+func isLoadedByName(name string) (bool, error) {
+	objs, err := loadedObjects()
+	if err != nil {
+		return false, err
+	}
+	for _, o := range objs {
+		if o.Name != "" && (o.Name == name || filepath.Base(o.Name) == filepath.Base(name)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,27 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// IsLoaded reports whether the named DLL is already loaded into the process, without loading it and without keeping a reference to it.
+// Use it to decide whether to use a DLL something else has loaded or to load a bundled copy of your own.
+// This is GetModuleHandleEx(); a name without a directory matches a loaded DLL with that file name wherever it was loaded from.
+func IsLoaded(name string) (bool, error) {
+	var h syscall.Handle
+
+	wname, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return false, err
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	r, _, _ := getModuleHandleExW.Call(getModuleHandleExFlagUnchangedRefcount,
+		uintptr(unsafe.Pointer(wname)), uintptr(unsafe.Pointer(&h)))
+	return r != 0, nil
+}
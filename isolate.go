@@ -0,0 +1,468 @@
+// 14 october 2026
+
+//go:build unix || windows
+
+package dl
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ErrHelperDied is returned by an Isolated whose helper process has exited, whether because the library crashed it or for any other reason.
+// The helper is not restarted; open the library again to get a new one.
+var ErrHelperDied = errors.New("dl: isolated helper process died")
+
+// Isolated is a library loaded in a helper process instead of this one, as returned by OpenIsolated.
+// If the library crashes, corrupts memory, or calls exit(), only the helper goes down; this process gets ErrHelperDied and carries on.
+// It is safe for concurrent use, but calls are made one at a time.
+type Isolated struct {
+	name	string
+	mu		sync.Mutex		// guards everything below, and keeps requests and responses in step
+	cmd		*exec.Cmd
+	r		io.ReadCloser
+	w		io.WriteCloser
+	enc		*gob.Encoder
+	dec		*gob.Decoder
+	dead		error			// set once the helper is gone
+}
+
+// isolatedEnv is set in the environment of a helper process; its value is the fd numbers of the helper's end of the pipes, or empty to use standard input and output.
+const isolatedEnv = "GO_DL_ISOLATED_HELPER"
+
+// isolatedRequest is what an Isolated sends its helper.
+type isolatedRequest struct {
+	Op		string		// "open", "symbol", "call", or "close"
+	Name	string		// library for "open"; symbol for "symbol" and "call"
+	Mode	Mode
+	Ret		CType
+	Args		[]CType
+	Values	[]isolatedValue
+}
+
+// isolatedResponse is what the helper sends back.
+type isolatedResponse struct {
+	Addr		uintptr		// for "symbol"
+	Value	isolatedValue	// for "call"
+	Err		*isolatedError
+}
+
+// isolatedValue carries an argument or result of a call; which field is used depends on its CType.
+type isolatedValue struct {
+	Nil		bool			// CVoid, or a NULL CString
+	Int		uint64		// any integer type, as its bit pattern
+	Float	float64
+	Pointer	uintptr		// CPointer; an address in the helper
+	String	string
+}
+
+// isolatedError carries an *Error from the helper.
+type isolatedError struct {
+	Op, Library, Symbol, Msg	string
+	Kind					int		// index into isolatedKinds, or -1
+}
+
+// isolatedKinds are the errors an isolatedError can wrap.
+var isolatedKinds = []error{ErrNotFound, ErrBadFormat, ErrSymbolNotFound, ErrClosed, ErrUnsupported, ErrInvalidMode}
+
+// OpenIsolated opens the named library in a new helper process, rather than in this one, so that crashes (and most mischief) in the library can't take this process down with it; use it for third-party plugins and codecs you don't trust.
+// The helper is this same program, started again with a variable in its environment; the program's main must call ServeIsolatedHelper first thing for that to work, and OpenIsolated returns an error if it hasn't.
+// The program's package init functions run in the helper too.
+// The helper has its own address space, so what crosses over is limited: Symbol returns addresses in the helper, which are only good for telling whether a symbol exists, and Call can only pass numbers and strings (see Isolated.Call).
+// The helper's standard error is this process's; on Unix, so is its standard output.
+//
+// name is resolved in this process, as Open would (see SetResolver and SetPolicy), and the Policy, if any, is asked about it before the helper is started; the helper is only ever given the resulting absolute path.
+// Where Resolve isn't available (Windows and OS X), name must be a path to the library.
+func OpenIsolated(name string, mode Mode) (*Isolated, error) {
+	if !isolatedServed.Load() {
+		return nil, fmt.Errorf("dl: OpenIsolated(%s) needs main to call ServeIsolatedHelper first", name)
+	}
+	path, err := isolatedPath(name)
+	if err != nil {
+		return nil, err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("dl: could not find the program to start a helper for %s: %w", name, err)
+	}
+	i := &Isolated{
+		name:	name,
+		cmd:		exec.Command(exe),
+	}
+	i.cmd.Stderr = os.Stderr
+	r, w, started, err := helperPipes(i.cmd)
+	if err != nil {
+		return nil, fmt.Errorf("dl: could not start a helper for %s: %w", name, err)
+	}
+	err = i.cmd.Start()
+	started()
+	if err != nil {
+		r.Close()
+		w.Close()
+		return nil, fmt.Errorf("dl: could not start a helper for %s: %w", name, err)
+	}
+	i.r = r
+	i.w = w
+	i.enc = gob.NewEncoder(w)
+	i.dec = gob.NewDecoder(r)
+	if _, err := i.do(isolatedRequest{
+		Op:		"open",
+		Name:	path,
+		Mode:	mode,
+	}); err != nil {
+		i.Close()
+		return nil, err
+	}
+	return i, nil
+}
+
+// isolatedPath resolves name to the absolute path of the library for OpenIsolated and checks it with the Policy.
+func isolatedPath(name string) (string, error) {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	var path string
+	var err error
+	if resolver != nil {
+		path, err = resolver(name)
+	} else {
+		path, err = Resolve(name)
+		if errors.Is(err, ErrUnsupported) && strings.ContainsRune(name, filepath.Separator) {
+			path, err = name, nil
+		}
+	}
+	if err == nil {
+		path, err = filepath.Abs(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := checkPolicy(name, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Symbol reports whether the library in the helper defines the named symbol, and returns its address there.
+// The address means nothing in this process; don't dereference it or call it.
+func (i *Isolated) Symbol(name string) (uintptr, error) {
+	r, err := i.do(isolatedRequest{
+		Op:		"symbol",
+		Name:	name,
+	})
+	return r.Addr, err
+}
+
+// Call calls the named function in the helper, which returns ret and takes arguments of the types given in args, with the given values; it is NewFunc and Func.Call done in the helper, so it needs libffi there.
+// Only values that mean the same thing in both processes can be passed: integers, floats, strings for CString, and nil or a uintptr address in the helper (such as one returned by an earlier Call) for CPointer.
+// Results are as for Func.Call, except that a CPointer result is a uintptr, since it's an address in the helper.
+// If the function crashes the helper, the error wraps ErrHelperDied.
+func (i *Isolated) Call(name string, ret CType, args []CType, values ...interface{}) (interface{}, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("dl: %s takes %d arguments; %d given", name, len(args), len(values))
+	}
+	req := isolatedRequest{
+		Op:		"call",
+		Name:	name,
+		Ret:		ret,
+		Args:		args,
+		Values:	make([]isolatedValue, len(values)),
+	}
+	for n, a := range values {
+		v, err := toIsolated(args[n], a)
+		if err != nil {
+			return nil, fmt.Errorf("dl: argument %d: %w", n, err)
+		}
+		req.Values[n] = v
+	}
+	r, err := i.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return fromIsolated(ret, r.Value), nil
+}
+
+// Close closes the library and stops the helper.
+// Closing an Isolated whose helper has died returns nil; the error it died with was already returned by whatever call found out.
+func (i *Isolated) Close() error {
+	_, err := i.do(isolatedRequest{ Op: "close" })
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.w != nil {
+		i.stop()
+	}
+	i.dead = &Error{
+		Op:		"close",
+		Library:	i.name,
+		Err:		ErrClosed,
+		Msg:		"dl: isolated " + i.name + " is closed",
+	}
+	if errors.Is(err, ErrHelperDied) {
+		return nil
+	}
+	return err
+}
+
+// do sends req to the helper and waits for its response.
+func (i *Isolated) do(req isolatedRequest) (isolatedResponse, error) {
+	var r isolatedResponse
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.dead != nil {
+		return r, i.dead
+	}
+	err := i.enc.Encode(&req)
+	if err == nil {
+		err = i.dec.Decode(&r)
+	}
+	if err != nil {
+		// whatever went wrong, the stream can't be trusted now, so the helper is done for
+		if werr := i.stop(); werr != nil {
+			err = werr
+		}
+		i.dead = fmt.Errorf("%w: %s: %v", ErrHelperDied, i.name, err)
+		return r, i.dead
+	}
+	if r.Err != nil {
+		e := &Error{
+			Op:		r.Err.Op,
+			Library:	r.Err.Library,
+			Symbol:	r.Err.Symbol,
+			Msg:		r.Err.Msg,
+		}
+		if r.Err.Kind >= 0 && r.Err.Kind < len(isolatedKinds) {
+			e.Err = isolatedKinds[r.Err.Kind]
+		}
+		return r, e
+	}
+	return r, nil
+}
+
+// stop closes this side of the conversation with the helper and waits for it to exit, returning how it did.
+// The caller must hold i.mu.
+func (i *Isolated) stop() error {
+	i.w.Close()
+	i.w = nil
+	err := i.cmd.Wait()
+	i.r.Close()
+	return err
+}
+
+// toIsolated converts an argument to Isolated.Call for sending to the helper.
+func toIsolated(t CType, a interface{}) (isolatedValue, error) {
+	var v isolatedValue
+
+	switch t {
+	case CString:
+		switch x := a.(type) {
+		case nil:
+			v.Nil = true
+		case string:
+			v.String = x
+		default:
+			return v, fmt.Errorf("%T is not a string", a)
+		}
+		return v, nil
+	case CPointer:
+		switch x := a.(type) {
+		case nil:
+		case uintptr:
+			v.Pointer = x
+		default:
+			return v, fmt.Errorf("%T is not an address in the helper", a)
+		}
+		return v, nil
+	}
+	rv := reflect.ValueOf(a)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.Int = uint64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.Int = rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		v.Float = rv.Float()
+	default:
+		return v, fmt.Errorf("%T can't be passed to an isolated library", a)
+	}
+	if t == CFloat || t == CDouble {
+		if rv.Kind() != reflect.Float32 && rv.Kind() != reflect.Float64 {
+			return v, fmt.Errorf("%T is not a float", a)
+		}
+	} else if rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64 {
+		return v, fmt.Errorf("%T is not an integer", a)
+	}
+	return v, nil
+}
+
+// fromIsolated converts a result from the helper the way Func.Call would.
+func fromIsolated(t CType, v isolatedValue) interface{} {
+	switch t {
+	case CVoid:
+		return nil
+	case CFloat, CDouble:
+		return v.Float
+	case CPointer:
+		return v.Pointer
+	case CString:
+		return v.String
+	case CInt8, CInt16, CInt32, CInt64, CInt, CLong:
+		return int64(v.Int)
+	}
+	return v.Int
+}
+
+// isolatedServed is set once ServeIsolatedHelper has been called, so OpenIsolated knows a helper will do what it's asked instead of running the program again.
+var isolatedServed atomic.Bool
+
+// ServeIsolatedHelper makes this program able to act as the helper process for OpenIsolated; call it first thing in main, before anything else has a chance to run.
+// In the helper, it loads the library the parent process asks for and serves its requests, then exits, never returning; in any other process, it returns at once.
+// Programs that never call it can't use OpenIsolated.
+//
+// A process is the helper if its environment has a certain variable set, which names the pipes to take requests over.
+// Anyone who can start the program with that variable and those pipes can have it load any library they like and call functions in it, with whatever privileges the program has; the helper trusts its parent, and does not apply a Policy, since its parent already has.
+// So don't call ServeIsolatedHelper in programs that run with more privilege than whoever sets their environment, such as setuid programs or services started on someone else's behalf.
+func ServeIsolatedHelper() {
+	isolatedServed.Store(true)
+	fds, ok := os.LookupEnv(isolatedEnv)
+	if !ok {
+		return
+	}
+	os.Unsetenv(isolatedEnv)		// so the library can start programs of its own
+	r, w, err := helperConn(fds)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dl: isolated helper: %v\n", err)
+		os.Exit(2)
+	}
+	serveIsolated(r, w)
+	os.Exit(0)
+}
+
+// serveIsolated is the helper's side of an Isolated; it returns when the other side closes the connection or asks it to.
+func serveIsolated(r io.Reader, w io.Writer) {
+	var m Module
+
+	funcs := make(map[string]*Func)
+	enc := gob.NewEncoder(w)
+	dec := gob.NewDecoder(r)
+	for {
+		var req isolatedRequest
+		var resp isolatedResponse
+		var err error
+
+		if dec.Decode(&req) != nil {
+			return
+		}
+		switch req.Op {
+		case "open":
+			m, err = Open(req.Name, req.Mode)
+		case "symbol":
+			var p unsafe.Pointer
+			p, err = m.Symbol(req.Name)
+			resp.Addr = uintptr(p)
+		case "call":
+			resp.Value, err = callIsolated(m, funcs, req)
+		case "close":
+			if m != 0 {
+				err = m.Close()
+			}
+			resp.Err = toIsolatedError(err)
+			enc.Encode(&resp)
+			return
+		default:
+			err = fmt.Errorf("dl: isolated helper: unknown request %q", req.Op)
+		}
+		resp.Err = toIsolatedError(err)
+		if enc.Encode(&resp) != nil {
+			return
+		}
+	}
+}
+
+// callIsolated makes a call for serveIsolated, reusing the Func for each function and signature.
+func callIsolated(m Module, funcs map[string]*Func, req isolatedRequest) (isolatedValue, error) {
+	var v isolatedValue
+
+	key := fmt.Sprint(req.Name, req.Ret, req.Args)
+	f := funcs[key]
+	if f == nil {
+		p, err := m.StrictSymbol(req.Name)
+		if err != nil {
+			return v, err
+		}
+		f, err = NewFunc(p, req.Ret, req.Args...)
+		if err != nil {
+			return v, err
+		}
+		funcs[key] = f
+	}
+	args := make([]interface{}, len(req.Values))
+	for n, a := range req.Values {
+		switch req.Args[n] {
+		case CString:
+			if !a.Nil {
+				args[n] = a.String
+			}
+		case CPointer:
+			args[n] = a.Pointer
+		case CFloat, CDouble:
+			args[n] = a.Float
+		default:
+			args[n] = a.Int
+		}
+	}
+	res, err := f.Call(args...)
+	if err != nil {
+		return v, err
+	}
+	switch x := res.(type) {
+	case nil:
+		v.Nil = true
+	case int64:
+		v.Int = uint64(x)
+	case uint64:
+		v.Int = x
+	case float64:
+		v.Float = x
+	case unsafe.Pointer:
+		v.Pointer = uintptr(x)
+	case string:
+		v.String = x
+	}
+	return v, nil
+}
+
+// toIsolatedError converts an error in the helper for sending back.
+func toIsolatedError(err error) *isolatedError {
+	if err == nil {
+		return nil
+	}
+	ie := &isolatedError{
+		Msg:		err.Error(),
+		Kind:	-1,
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		ie.Op, ie.Library, ie.Symbol = e.Op, e.Library, e.Symbol
+	}
+	for n, kind := range isolatedKinds {
+		if errors.Is(err, kind) {
+			ie.Kind = n
+			break
+		}
+	}
+	if !strings.HasPrefix(ie.Msg, "dl: ") && e == nil {
+		ie.Msg = "dl: isolated helper: " + ie.Msg
+	}
+	return ie
+}
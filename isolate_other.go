@@ -0,0 +1,33 @@
+// 14 october 2026
+
+//go:build !unix && !windows
+
+package dl
+
+// Isolated is a library loaded in a helper process instead of this one.
+// This system can't load libraries, so there are none.
+type Isolated struct{}
+
+// OpenIsolated returns ErrUnsupported on this system.
+func OpenIsolated(name string, mode Mode) (*Isolated, error) {
+	return nil, ErrUnsupported
+}
+
+// ServeIsolatedHelper does nothing on this system, since there are never any helpers.
+func ServeIsolatedHelper() {
+}
+
+// Symbol returns ErrUnsupported on this system.
+func (i *Isolated) Symbol(name string) (uintptr, error) {
+	return 0, ErrUnsupported
+}
+
+// Call returns ErrUnsupported on this system.
+func (i *Isolated) Call(name string, ret CType, args []CType, values ...interface{}) (interface{}, error) {
+	return nil, ErrUnsupported
+}
+
+// Close returns ErrUnsupported on this system.
+func (i *Isolated) Close() error {
+	return ErrUnsupported
+}
@@ -0,0 +1,70 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestMain(m *testing.M) {
+	dl.ServeIsolatedHelper()
+	os.Exit(m.Run())
+}
+
+const isolatedSource = `
+#include <unistd.h>
+int add(int a, int b) { return a + b; }
+void boom(void) { _exit(3); }
+`
+
+func TestIsolatedRoundTrip(t *testing.T) {
+	lib := dltest.Build(t, isolatedSource)
+	i, err := dl.OpenIsolated(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("OpenIsolated(%q): %v", lib, err)
+	}
+	defer i.Close()
+
+	if addr, err := i.Symbol("add"); err != nil || addr == 0 {
+		t.Errorf("Symbol(add) = %#x, %v; want an address", addr, err)
+	}
+	if _, err := i.Symbol("nothere"); !errors.Is(err, dl.ErrSymbolNotFound) {
+		t.Errorf("Symbol(nothere) error = %v; want ErrSymbolNotFound", err)
+	}
+	v, err := i.Call("add", dl.CInt, []dl.CType{dl.CInt, dl.CInt}, 2, 3)
+	if errors.Is(err, dl.ErrUnsupported) {
+		t.Skipf("Call needs libffi: %v", err)
+	}
+	if err != nil || v != int64(5) {
+		t.Errorf("Call(add, 2, 3) = %v, %v; want 5", v, err)
+	}
+	if _, err := i.Call("boom", dl.CVoid, nil); !errors.Is(err, dl.ErrHelperDied) {
+		t.Errorf("Call(boom) error = %v; want ErrHelperDied", err)
+	}
+	if _, err := i.Symbol("add"); !errors.Is(err, dl.ErrHelperDied) {
+		t.Errorf("Symbol after the helper died error = %v; want ErrHelperDied", err)
+	}
+}
+
+func TestIsolatedPolicy(t *testing.T) {
+	lib := dltest.Build(t, isolatedSource)
+	var checked string
+	dl.SetPolicy(func(name string, path string) error {
+		checked = path
+		return errors.New("no")
+	})
+	defer dl.SetPolicy(nil)
+	if _, err := dl.OpenIsolated(lib, dl.Now); !errors.Is(err, dl.ErrDenied) {
+		t.Errorf("OpenIsolated with a denying Policy error = %v; want ErrDenied", err)
+	}
+	if checked != lib {
+		t.Errorf("Policy was asked about %q; want %q", checked, lib)
+	}
+}
@@ -0,0 +1,44 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// helperPipes arranges for cmd to talk to this process over a pair of pipes given to it as fds 3 and 4, so the library in it can use standard input and output as usual.
+// It returns this process's ends, and a function to call once cmd has started, which closes the helper's ends in this process (otherwise neither side would see the other go away).
+func helperPipes(cmd *exec.Cmd) (io.ReadCloser, io.WriteCloser, func(), error) {
+	reqr, reqw, err := os.Pipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	respr, respw, err := os.Pipe()
+	if err != nil {
+		reqr.Close()
+		reqw.Close()
+		return nil, nil, nil, err
+	}
+	cmd.Stdout = os.Stdout
+	cmd.ExtraFiles = []*os.File{reqr, respw}
+	cmd.Env = append(os.Environ(), isolatedEnv + "=3,4")
+	return respr, reqw, func() {
+		reqr.Close()
+		respw.Close()
+	}, nil
+}
+
+// helperConn returns the helper's ends of the pipes helperPipes made, from the value of isolatedEnv.
+func helperConn(fds string) (io.Reader, io.Writer, error) {
+	var r, w int
+
+	if _, err := fmt.Sscanf(fds, "%d,%d", &r, &w); err != nil {
+		return nil, nil, fmt.Errorf("bad %s %q", isolatedEnv, fds)
+	}
+	return os.NewFile(uintptr(r), "request"), os.NewFile(uintptr(w), "response"), nil
+}
@@ -0,0 +1,29 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+// helperPipes arranges for cmd to talk to this process over its standard input and output, since Windows can't hand a child other handles through os/exec.
+// So on Windows, anything the library writes to standard output garbles the conversation and kills the helper.
+func helperPipes(cmd *exec.Cmd) (io.ReadCloser, io.WriteCloser, func(), error) {
+	w, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cmd.Env = append(os.Environ(), isolatedEnv + "=")
+	return r, w, func() {}, nil
+}
+
+// helperConn returns the helper's side of the conversation helperPipes set up.
+func helperConn(fds string) (io.Reader, io.Writer, error) {
+	return os.Stdin, os.Stdout, nil
+}
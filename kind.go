@@ -0,0 +1,75 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// SymbolKind says whether a symbol is code or data.
+type SymbolKind int
+
+const (
+	UnknownKind SymbolKind = iota	// neither the library nor the system says
+	FuncKind					// a function
+	DataKind					// a variable or other data
+)
+
+func (k SymbolKind) String() string {
+	switch k {
+	case FuncKind:
+		return "function"
+	case DataKind:
+		return "data"
+	}
+	return "unknown"
+}
+
+// ErrWrongKind is returned, wrapped, by FuncSymbol and DataSymbol when the symbol is not the kind asked for.
+var ErrWrongKind = errors.New("dl: symbol is of the wrong kind")
+
+// FuncSymbol is like Symbol, but fails if the symbol is not a function.
+// Use it for symbols you are about to call; calling a variable by mistake otherwise crashes somewhere deep inside the library.
+// The kind comes from the library's symbol table; this is only possible on Linux, so FuncSymbol returns ErrUnsupported elsewhere.
+// Symbols with no recorded kind, which can happen with ones written in assembly, are an error too.
+func (m Module) FuncSymbol(name string) (unsafe.Pointer, error) {
+	return m.kindSymbol(name, FuncKind)
+}
+
+// DataSymbol is like Symbol, but fails if the symbol is not data.
+// Apart from that it works like FuncSymbol.
+func (m Module) DataSymbol(name string) (unsafe.Pointer, error) {
+	return m.kindSymbol(name, DataKind)
+}
+
+func (m Module) kindSymbol(name string, want SymbolKind) (unsafe.Pointer, error) {
+	p, err := m.Symbol(name)
+	if err != nil {
+		return nil, err
+	}
+	k, err := m.symbolKind(name, p)
+	if err != nil {
+		return nil, err
+	}
+	if k == UnknownKind {
+		return nil, fmt.Errorf("dl: cannot tell whether %s is a function or data", name)
+	}
+	if k != want {
+		return nil, fmt.Errorf("%w: %s is %v, not %v", ErrWrongKind, name, k, want)
+	}
+	return p, nil
+}
+
+// kindOf maps the type of an ELF symbol to its SymbolKind.
+func kindOf(t elf.SymType) SymbolKind {
+	switch t {
+	case elf.STT_FUNC, elf.STT_GNU_IFUNC:
+		return FuncKind
+	case elf.STT_OBJECT, elf.STT_COMMON, elf.STT_TLS:
+		return DataKind
+	}
+	return UnknownKind
+}
@@ -0,0 +1,52 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <link.h>
+// #ifdef __GLIBC__
+// /* returns the ELF type of the symbol at exactly addr, or -1 if there isn't one */
+// static int symtype(void *addr)
+// {
+// 	Dl_info info;
+// 	ElfW(Sym) *sym = NULL;
+//
+// 	if (dladdr1(addr, &info, (void **) (&sym), RTLD_DL_SYMENT) == 0)
+// 		return -1;
+// 	if (sym == NULL || info.dli_saddr != addr)
+// 		return -1;
+// 	return sym->st_info & 0xf;		/* ELF32_ST_TYPE() and ELF64_ST_TYPE() are the same */
+// }
+// #else
+// static int symtype(void *addr) { return -1; }
+// #endif
+import "C"
+
+// symbolKind works out what kind of symbol name, found by Symbol at p, is.
+// glibc's dladdr1() can say directly; otherwise (or if p doesn't start a symbol in any dynamic symbol table, which is what happens for GNU indirect functions) look name up in m's own dynamic symbol table.
+func (m Module) symbolKind(name string, p unsafe.Pointer) (SymbolKind, error) {
+	if p != nil {
+		dllock.Lock()
+		t := C.symtype(p)
+		dllock.Unlock()
+		if t >= 0 {
+			return kindOf(elf.SymType(t)), nil
+		}
+	}
+	syms, err := m.ExportedSymbols()
+	if err != nil {
+		return UnknownKind, err
+	}
+	for _, s := range syms {
+		if s.Name == name && (s.Addr == uintptr(p) || s.Type == elf.STT_GNU_IFUNC) {
+			return kindOf(s.Type), nil
+		}
+	}
+	return UnknownKind, nil		// defined in one of m's dependencies, which we can't tell apart
+}
@@ -0,0 +1,14 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// TODO dladdr() can't say what kind a symbol is; reading the symbol table of the file (see Path) would be able to
+func (m Module) symbolKind(name string, p unsafe.Pointer) (SymbolKind, error) {
+	return UnknownKind, ErrUnsupported
+}
@@ -0,0 +1,89 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// LazyModule is a library that is not opened until it is first needed.
+// It implements ModuleHandle, so code that only needs symbols can take one in place of a Module.
+// LazyModules are safe for concurrent use.
+type LazyModule struct {
+	name		string
+	mode	Mode
+
+	lock		sync.Mutex
+	tried		bool
+	m		Module
+	err		error
+	closed	bool
+}
+
+// LazyOpen returns a LazyModule for the named library, which will be opened with Open the first time Symbol or Module is called on it, and only then.
+// This lets a program set up every optional backend it knows about without loading any it doesn't use.
+// A failed open is not retried; every later call returns the same error.
+func LazyOpen(name string, mode Mode) *LazyModule {
+	return &LazyModule{
+		name:	name,
+		mode:	mode,
+	}
+}
+
+// Module opens the library if it hasn't been already, and returns it or the error from opening it.
+func (l *LazyModule) Module() (Module, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return 0, l.closedError("open")
+	}
+	if !l.tried {
+		l.m, l.err = Open(l.name, l.mode)
+		l.tried = true
+	}
+	return l.m, l.err
+}
+
+// Symbol opens the library if it hasn't been already, then looks up the named symbol in it.
+// If the library can't be opened, the error from Open is returned.
+func (l *LazyModule) Symbol(name string) (unsafe.Pointer, error) {
+	m, err := l.Module()
+	if err != nil {
+		return nil, err
+	}
+	return m.Symbol(name)
+}
+
+// Opened returns whether the library has been opened successfully yet, without opening it.
+func (l *LazyModule) Opened() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.tried && l.err == nil && !l.closed
+}
+
+// Close closes the library if it was opened; if it wasn't, Close only stops it from ever being opened.
+// Using the LazyModule afterward, including closing it again, returns an error wrapping ErrClosed.
+func (l *LazyModule) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.closed {
+		return l.closedError("close")
+	}
+	l.closed = true
+	if l.tried && l.err == nil {
+		return l.m.Close()
+	}
+	return nil
+}
+
+func (l *LazyModule) closedError(op string) error {
+	return &Error{
+		Op:		op,
+		Library:	l.name,
+		Err:		ErrClosed,
+		Msg:		"dl: " + l.name + " is already closed",
+	}
+}
@@ -0,0 +1,25 @@
+// 14 october 2026
+
+package dl
+
+var leakOnClose = false
+
+// SetLeakOnClose controls whether Close and CloseAll actually give libraries back to the system.
+// While it is on, they do all of their bookkeeping (so using a closed Module still returns ErrClosed) but never call dlclose() or FreeLibrary(), so closed libraries stay mapped.
+// This is for running under AddressSanitizer or Valgrind, and for getting core dumps, where addresses in a library that has been unloaded can no longer be turned into symbols and line numbers.
+// Libraries closed while it is on stay loaded for the rest of the process's life, even if it is turned off again.
+// It is off by default.
+func SetLeakOnClose(on bool) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	leakOnClose = on
+}
+
+// unload gives back one reference to m from the system, unless SetLeakOnClose(true) is in effect.
+// The caller must hold dllock.
+func (m Module) unload() error {
+	if leakOnClose {
+		return nil
+	}
+	return m.sysclose()
+}
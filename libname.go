@@ -0,0 +1,59 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"runtime"
+	"strings"
+)
+
+// LibraryName returns the file name this system uses for the library base with the given version, which is given as separate components:
+// 	LibraryName("foo", "3")		"libfoo.so.3" on ELF systems, "libfoo.3.dylib" on OS X, "foo.dll" on Windows
+// 	LibraryName("foo", "3", "1")	"libfoo.so.3.1", "libfoo.3.1.dylib", "foo.dll"
+// 	LibraryName("foo")			"libfoo.so", "libfoo.dylib", "foo.dll"
+// The lib prefix is added on Unix systems unless base already has it.
+// Windows has no convention for versions in DLL names, so version is ignored there; LibraryNames suggests some.
+func LibraryName(base string, version ...string) string {
+	return LibraryNames(base, version...)[0]
+}
+
+// LibraryNames is like LibraryName, but returns a list of names to try in order, from most to least specific.
+// For instance, LibraryNames("foo", "3", "1") gives "libfoo.so.3.1", "libfoo.so.3", and "libfoo.so" on ELF systems.
+// On Windows, the list also includes the names MinGW builds tend to use: "foo.dll", "foo-3.dll", "libfoo.dll", and "libfoo-3.dll".
+// Pass the result to OpenCandidates to open whichever one the system has.
+func LibraryNames(base string, version ...string) []string {
+	var names []string
+
+	if runtime.GOOS == "windows" {
+		plain := strings.TrimPrefix(base, "lib")
+		names = append(names, base + ".dll")
+		if len(version) != 0 {
+			names = append(names, base + "-" + version[0] + ".dll")
+		}
+		if plain == base {
+			names = append(names, "lib" + base + ".dll")
+			if len(version) != 0 {
+				names = append(names, "lib" + base + "-" + version[0] + ".dll")
+			}
+		}
+		return names
+	}
+
+	if !strings.HasPrefix(base, "lib") {
+		base = "lib" + base
+	}
+	for i := len(version); i >= 0; i-- {
+		v := strings.Join(version[:i], ".")
+		switch {
+		case runtime.GOOS == "darwin" && v != "":
+			names = append(names, base + "." + v + ".dylib")
+		case runtime.GOOS == "darwin":
+			names = append(names, base + ".dylib")
+		case v != "":
+			names = append(names, base + ".so." + v)
+		default:
+			names = append(names, base + ".so")
+		}
+	}
+	return names
+}
@@ -0,0 +1,165 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// Library is a library opened by New, along with what New found out about it.
+// The Module methods are available on it directly; close it with Close as usual.
+type Library struct {
+	Module
+
+	// Name is the name or path that was opened, out of all those New tried.
+	Name		string
+
+	// Symbols holds the symbols given to WithRequiredSymbols, found when the library was opened.
+	Symbols	map[string]unsafe.Pointer
+
+	// Constructors holds, with WithConstructorReport, the constructors that ran while the library was opened.
+	Constructors	[]InitFunction
+}
+
+// Option is an option to New.
+type Option func(*options)
+
+type options struct {
+	mode		Mode
+	candidates	[]string
+	dirs		[]string
+	required	[]string
+	report	bool
+}
+
+// WithMode sets the mode the library is opened with; it is Now if not given.
+func WithMode(mode Mode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+// WithCandidates sets the file names to try, in order, instead of those New works out from the name it was given.
+// Giving it more than once adds to the list.
+func WithCandidates(names ...string) Option {
+	return func(o *options) {
+		o.candidates = append(o.candidates, names...)
+	}
+}
+
+// WithSearchDirs makes New look in the given directories, in order, before letting the system search for the library.
+// Each candidate file name is tried in the first directory, then each in the second, and so on; files that don't exist are skipped.
+// Giving it more than once adds to the list.
+func WithSearchDirs(dirs ...string) Option {
+	return func(o *options) {
+		o.dirs = append(o.dirs, dirs...)
+	}
+}
+
+// WithRequiredSymbols makes New fail, listing every one that is missing, unless the library has all of the named symbols.
+// The symbols are then available in the Library's Symbols.
+// Giving it more than once adds to the list.
+func WithRequiredSymbols(names ...string) Option {
+	return func(o *options) {
+		o.required = append(o.required, names...)
+	}
+}
+
+// WithConstructorReport makes New fill in the Library's Constructors with every constructor that ran while it was opened: those of the library itself and of each dependency that was loaded along with it, grouped by object in the order the objects were loaded (which is not quite the order they run in; dependencies run first).
+// The objects loaded are worked out by comparing LoadedObjects before and after, so anything another goroutine loads at the same time is counted too.
+// Where InitFunctions doesn't work, Constructors is left nil.
+func WithConstructorReport() Option {
+	return func(o *options) {
+		o.report = true
+	}
+}
+
+// New opens a library, configured by the given options.
+// Unless WithCandidates says otherwise, the file names tried are first name itself and then, if name has no extension or directory, what LibraryNames gives for it; New("foo") tries "foo", "libfoo.so", and so on.
+// The first name that opens is used, as with OpenCandidates, and if none can be opened, the error lists why each one failed.
+func New(name string, opts ...Option) (*Library, error) {
+	o := &options{
+		mode:	Now,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.candidates) == 0 {
+		o.candidates = []string{name}
+		if filepath.Base(name) == name && !strings.Contains(name, ".") {
+			o.candidates = append(o.candidates, LibraryNames(name)...)
+		}
+	}
+
+	var names []string
+	for _, dir := range o.dirs {
+		for _, c := range o.candidates {
+			if filepath.IsAbs(c) {
+				continue
+			}
+			p := filepath.Join(dir, c)
+			if _, err := os.Stat(p); err == nil {
+				names = append(names, p)
+			}
+		}
+	}
+	names = append(names, o.candidates...)
+
+	// the lock is held throughout so that nothing can be unloaded while its constructors are being read
+	var before []LoadedObject
+	var constructors []InitFunction
+	dllock.Lock()
+	if o.report {
+		before, _ = loadedObjects()
+	}
+	m, opened, err := openCandidates(names, o.mode)
+	if err == nil && o.report {
+		constructors = constructorsSince(before)
+	}
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	l := &Library{
+		Module:		m,
+		Name:		opened,
+		Constructors:	constructors,
+	}
+	if len(o.required) != 0 {
+		l.Symbols, err = m.Symbols(o.required...)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// constructorsSince lists the constructors of every object loaded that isn't in before.
+// The caller must hold dllock.
+func constructorsSince(before []LoadedObject) []InitFunction {
+	var fns []InitFunction
+
+	after, err := loadedObjects()
+	if err != nil {
+		return nil
+	}
+	old := make(map[uintptr]bool, len(before))
+	for _, obj := range before {
+		old[obj.Base] = true
+	}
+	for _, obj := range after {
+		if old[obj.Base] || obj.Name == "" {
+			continue
+		}
+		f, err := initFunctions(obj.Name, obj.Base)
+		if err != nil {
+			continue		// the vDSO, for one, has no file
+		}
+		fns = append(fns, f...)
+	}
+	return fns
+}
@@ -0,0 +1,65 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <link.h>
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// static int dlinfoerr(void *handle, int request, void *info, char **err)
+// {
+// 	int r;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	r = dlinfo(handle, request, info);
+// 	if (r != 0)
+// 		*err = dlerrordup();
+// 	return r;
+// }
+import "C"
+
+func init() {
+	features[FeatureDlinfo] = true
+}
+
+// linkmap returns the dynamic linker's link_map entry for m.
+// Pseudo-Modules have none, and dlinfo() crashes on them rather than failing, so they are rejected here; everything built on linkmap and object inherits that.
+// The caller must hold dllock.
+func (m Module) linkmap() (*C.struct_link_map, error) {
+	var lm *C.struct_link_map
+	var e *C.char
+
+	if m.pseudo() {
+		return nil, errPseudoPath
+	}
+	if err := m.closedError("info", ""); err != nil {
+		return nil, err
+	}
+	if C.dlinfoerr(unsafe.Pointer(m), C.RTLD_DI_LINKMAP, unsafe.Pointer(&lm), &e) != 0 {
+		return nil, takeError(e)
+	}
+	return lm, nil
+}
+
+// object returns the file backing m.
+// The main program has an empty name in the link map, so /proc/self/exe is reported for it.
+// The caller must hold dllock.
+func (m Module) object() (*object, error) {
+	lm, err := m.linkmap()
+	if err != nil {
+		return nil, err
+	}
+	o := &object{
+		path:	C.GoString(lm.l_name),
+		bias:	uintptr(lm.l_addr),
+	}
+	if o.path == "" {
+		o.path = "/proc/self/exe"
+	}
+	return o, nil
+}
@@ -0,0 +1,42 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// ModuleHandle is what code that only looks up symbols needs from a library.
+// Module implements it.
+type ModuleHandle interface {
+	Symbol(name string) (unsafe.Pointer, error)
+	Close() error
+}
+
+// Loader opens libraries.
+// Write code that loads libraries against a Loader, rather than calling Open directly, and tests can give it a fake one (see package dltest) instead of needing real libraries on disk.
+type Loader interface {
+	Open(name string, mode Mode) (ModuleHandle, error)
+	OpenSelf(mode Mode) (ModuleHandle, error)
+}
+
+// SystemLoader is the Loader that uses the system's dynamic linker; its methods are Open and OpenSelf.
+var SystemLoader Loader = systemLoader{}
+
+type systemLoader struct{}
+
+func (systemLoader) Open(name string, mode Mode) (ModuleHandle, error) {
+	m, err := Open(name, mode)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (systemLoader) OpenSelf(mode Mode) (ModuleHandle, error) {
+	m, err := OpenSelf(mode)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
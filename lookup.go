@@ -0,0 +1,56 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Lookup returns the named global variable in m as a pointer to T, which should be the Go equivalent of the variable's C type (for instance, int32 for int, or a C.struct_ type from your cgo code).
+// As with VarPointer, a nil address is an error.
+// Nothing checks that T matches the variable; Lookup only saves the conversion from unsafe.Pointer.
+func Lookup[T any](m Module, name string) (*T, error) {
+	p, err := m.VarPointer(name)
+	if err != nil {
+		return nil, err
+	}
+	return (*T)(p), nil
+}
+
+// FuncHandle is a function pointer from a Module, labeled with the C function's signature written as a Go func type F.
+// The label isn't checked against the library; it exists so a handle for one kind of function can't be passed where another kind is expected without the compiler noticing.
+// To call the function, pass Pointer to cgo code or give it to NewFunc.
+type FuncHandle[F any] struct {
+	p	unsafe.Pointer
+}
+
+// Pointer returns the function pointer itself.
+func (h FuncHandle[F]) Pointer() unsafe.Pointer {
+	return h.p
+}
+
+// LookupFunc returns the named function in m as a FuncHandle.
+// Unlike Symbol, a nil address is an error, since there's nothing to call.
+// 	sqrt, err := dl.LookupFunc[func(float64) float64](libm, "sqrt")
+func LookupFunc[F any](m Module, name string) (FuncHandle[F], error) {
+	p, err := m.Symbol(name)
+	if err != nil {
+		return FuncHandle[F]{}, err
+	}
+	if p == nil {
+		return FuncHandle[F]{}, fmt.Errorf("dl: function %s has a nil address", name)
+	}
+	return FuncHandle[F]{ p: p }, nil
+}
+
+// SymbolUintptr is like Symbol, but returns the address as a uintptr.
+// This is the supported way to hand a symbol to call mechanisms that take addresses as uintptrs, such as syscall.SyscallN on Windows or github.com/ebitengine/purego, without cgo.
+// A uintptr is not a pointer as far as Go is concerned, which is fine here: the address is in the library, not in Go memory, and stays valid until the library is closed.
+func (m Module) SymbolUintptr(name string) (uintptr, error) {
+	p, err := m.Symbol(name)
+	if err != nil {
+		return 0, err
+	}
+	return uintptr(p), nil
+}
@@ -0,0 +1,55 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+)
+
+// LookupOK looks up the given named symbol in the Module and reports whether it was found, for probing optional features of a library:
+// 	if p, ok := m.LookupOK("avcodec_send_packet"); ok {
+// 		...
+// 	}
+// Unlike Symbol, a missing symbol is not an error, and costs no allocations to report; the dynamic linker's error message is never collected.
+// As with Symbol, a symbol can be found with a nil value.
+// A closed Module has no symbols to find.
+func (m Module) LookupOK(name string) (symbol unsafe.Pointer, ok bool) {
+	if t := m.boundThread(); t != nil {
+		return m.lookupOKOn(t, name)
+	}
+	defer symlock()()
+
+	start := time.Now()
+	mi := modules[m]
+	if mi == nil && m.closedError("symbol", name) != nil {
+		return nil, false
+	}
+	if mi != nil {
+		if s, ok := mi.cached(name); ok {
+			trace("symbol", mi.name, name, m, start, nil)
+			return s, true
+		}
+	}
+	if mi != nil && mi.rules != nil {
+		for _, n := range mi.rules.Names(name) {
+			if symbol, ok = m.sysprobe(n); ok {
+				break
+			}
+		}
+	} else {
+		symbol, ok = m.sysprobe(name)
+	}
+	if !ok {
+		if tracer != nil || instrumentation != nil {		// only make an error if someone will see it
+			trace("symbol", m.libraryName(), name, m, start, m.symbolError(name, errors.New("dl: symbol " + name + " not found")))
+		}
+		return nil, false
+	}
+	if mi != nil {
+		mi.cache(name, symbol)
+	}
+	trace("symbol", m.libraryName(), name, m, start, nil)
+	return symbol, true
+}
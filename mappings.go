@@ -0,0 +1,65 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"fmt"
+)
+
+// Mapping is a range of memory a Module occupies, as returned by Mappings.
+type Mapping struct {
+	Start	uintptr		// the first byte of the range
+	End		uintptr		// one past the last byte of the range
+	Perm	elf.ProgFlag	// elf.PF_R, elf.PF_W, and elf.PF_X
+}
+
+// Contains returns whether addr is in the range.
+func (p Mapping) Contains(addr uintptr) bool {
+	return addr >= p.Start && addr < p.End
+}
+
+// Mappings returns the ranges of memory m's loadable segments occupy, in the order the file lists them.
+// Use it to tell which library a faulting address belongs to, for example in a crash handler; build the list ahead of time, since the handler shouldn't call into the dynamic linker.
+// The ranges are those of the segments themselves, not rounded out to whole pages.
+// This only works on ELF systems where Info does, and returns ErrUnsupported elsewhere.
+func (m Module) Mappings() ([]Mapping, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.Mappings)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	o, err := m.object()
+	if err != nil {
+		return nil, err
+	}
+	objs, err := loadedObjects()
+	if err != nil {
+		return nil, err
+	}
+	var found *LoadedObject
+	for i := range objs {
+		if objs[i].Base != o.bias {
+			continue
+		}
+		if found == nil || objs[i].Name == o.path {
+			found = &objs[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("dl: could not find %s among the loaded objects", o.path)
+	}
+	var maps []Mapping
+	for _, s := range found.Segments {
+		if s.Type != elf.PT_LOAD || s.MemSize == 0 {
+			continue
+		}
+		maps = append(maps, Mapping{
+			Start:	s.Addr,
+			End:		s.Addr + uintptr(s.MemSize),
+			Perm:	s.Flags & (elf.PF_R | elf.PF_W | elf.PF_X),
+		})
+	}
+	return maps, nil
+}
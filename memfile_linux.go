@@ -0,0 +1,75 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// #include <unistd.h>
+// #include <sys/syscall.h>
+// #include <errno.h>
+// #ifdef SYS_memfd_create
+// #define haveMemfd 1
+// #else
+// #define haveMemfd 0
+// #endif
+// static int memfd(void)
+// {
+// #ifdef SYS_memfd_create
+// 	return syscall(SYS_memfd_create, "dl", 1);		/* MFD_CLOEXEC */
+// #else
+// 	errno = ENOSYS;
+// 	return -1;
+// #endif
+// }
+// static int stillLoaded(const char *path)
+// {
+// 	void *h;
+//
+// 	h = dlopen(path, RTLD_LAZY | RTLD_NOLOAD);
+// 	if (h == NULL)
+// 		return 0;
+// 	dlclose(h);		/* drop the reference dlopen() took */
+// 	return 1;
+// }
+import "C"
+
+// keptMemfiles holds the memory files of libraries that stayed loaded after their last Close, so their paths are never handed out again; guarded by dllock.
+var keptMemfiles []*os.File
+
+func init() {
+	features[FeatureMemfd] = C.haveMemfd != 0		// the kernel might still be too old
+}
+
+// memfile puts data in an anonymous memory file and returns a path dlopen() can open it by, and a function that closes it.
+// The file must stay open for as long as the library is loaded: the dynamic linker recognizes libraries it already has by path, and the path of a closed descriptor will be reused for the next one opened.
+// So if the library is still loaded when release is called (because of SetLeakOnClose, Nodelete, or references held elsewhere), the file is kept open for the rest of the process's life instead.
+// release must be called with dllock held.
+func memfile(data []byte) (path string, release func(), err error) {
+	fd, err := C.memfd()
+	if fd < 0 {
+		return "", nil, err
+	}
+	f := os.NewFile(uintptr(fd), "dl")
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", nil, err
+	}
+	path = fmt.Sprintf("/proc/self/fd/%d", fd)
+	release = func() {
+		cpath := C.CString(path)
+		defer C.free(unsafe.Pointer(cpath))
+		if C.stillLoaded(cpath) != 0 {
+			keptMemfiles = append(keptMemfiles, f)
+			return
+		}
+		f.Close()
+	}
+	return path, release, nil
+}
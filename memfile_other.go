@@ -0,0 +1,10 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// memfile is only implemented on Linux; elsewhere OpenBytes always uses a temporary file.
+func memfile(data []byte) (path string, release func(), err error) {
+	return "", nil, ErrUnsupported
+}
@@ -0,0 +1,107 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidMode is returned, wrapped, by Open and friends when given a Mode that makes no sense, such as Now|Lazy.
+var ErrInvalidMode = errors.New("dl: invalid mode")
+
+type modeName struct {
+	mode	Mode
+	name	string
+}
+
+// modeNames is used by String; each system adds its own modes.
+var modeNames = []modeName{
+	{ Now, "Now" },
+	{ Lazy, "Lazy" },
+	{ Global, "Global" },
+	{ Local, "Local" },
+}
+
+// conflictingModes lists pairs of modes that cannot be combined; each system adds its own pairs.
+var conflictingModes = [][2]Mode{
+	{ Now, Lazy },
+	{ Global, Local },
+}
+
+// String returns m as the names of its modes joined with |, such as "Lazy|Global".
+// Bits that aren't a known mode are given in hexadecimal at the end.
+// Modes that are 0 on the current system (Local, on some) never appear.
+func (m Mode) String() string {
+	var names []string
+
+	rest := m
+	for _, n := range modeNames {
+		if n.mode != 0 && rest & n.mode == n.mode {
+			names = append(names, n.name)
+			rest &^= n.mode
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("%#x", uintptr(rest)))
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "|")
+}
+
+// Has returns whether every mode in x is in m.
+// A mode that is 0 on the current system is in every Mode.
+func (m Mode) Has(x Mode) bool {
+	return m & x == x
+}
+
+// check returns the error Open and friends should return for m, or nil if m can be used.
+func (m Mode) check() error {
+	if !m.Supported() {
+		return ErrUnsupported
+	}
+	for _, c := range conflictingModes {
+		if m & c[0] != 0 && m & c[1] != 0 {
+			return fmt.Errorf("%w %v: %v and %v cannot be combined", ErrInvalidMode, m, c[0], c[1])
+		}
+	}
+	return nil
+}
+
+// ParseMode parses a Mode written the way String writes it, such as "Lazy|Global", for reading modes out of configuration files.
+// Case doesn't matter, spaces around each part are ignored, and numbers (like the hexadecimal ones String writes for unknown bits) are allowed.
+// Names of modes this system doesn't have are an error, as is a combination that Open would reject.
+func ParseMode(s string) (Mode, error) {
+	var m Mode
+
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return 0, fmt.Errorf("dl: missing mode in %q", s)
+		}
+		found := false
+		for _, n := range modeNames {
+			if strings.EqualFold(part, n.name) {
+				m |= n.mode
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		v, err := strconv.ParseUint(part, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("dl: unknown mode %q", part)
+		}
+		m |= Mode(v)
+	}
+	if err := m.check(); err != nil {
+		return 0, err
+	}
+	return m, nil
+}
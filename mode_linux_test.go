@@ -0,0 +1,11 @@
+// 14 october 2026
+
+package dl_test
+
+import (
+	"github.com/andlabs/dl"
+)
+
+func init() {
+	systemModes = append(systemModes, dl.Nodelete, dl.Noload, dl.Deepbind)
+}
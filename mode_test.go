@@ -0,0 +1,111 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestModeString(t *testing.T) {
+	for _, tt := range []struct {
+		m	dl.Mode
+		want	string
+	}{
+		{dl.Now, "Now"},
+		{dl.Lazy | dl.Global, "Lazy|Global"},
+		{dl.Global | dl.Lazy, "Lazy|Global"},
+		{dl.Now | 1 << 20, "Now|0x100000"},
+		{0, "0"},
+	} {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("Mode(%#x).String() = %q; want %q", uintptr(tt.m), got, tt.want)
+		}
+	}
+}
+
+func TestModeHas(t *testing.T) {
+	m := dl.Lazy | dl.Global
+	if !m.Has(dl.Lazy) || !m.Has(dl.Global) || !m.Has(dl.Lazy | dl.Global) {
+		t.Errorf("%v.Has is false for one of its own modes", m)
+	}
+	if m.Has(dl.Now) || m.Has(dl.Now | dl.Lazy) {
+		t.Errorf("%v.Has(Now) is true", m)
+	}
+}
+
+func TestOpenConflictingModes(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	for _, mode := range []dl.Mode{dl.Now | dl.Lazy, dl.Global | dl.Local} {
+		if mode == dl.Global {
+			continue		// Local is 0 here, so there is nothing to conflict
+		}
+		m, err := dl.Open(lib, mode)
+		if err == nil {
+			m.Close()
+			t.Errorf("Open with %v succeeded", mode)
+			continue
+		}
+		if !errors.Is(err, dl.ErrInvalidMode) {
+			t.Errorf("Open with %v error = %v; want ErrInvalidMode", mode, err)
+		}
+	}
+	if loaded, err := dl.IsLoaded(lib); err == nil && loaded {
+		t.Errorf("Open with conflicting modes loaded the library anyway")
+	}
+}
+
+// systemModes are the modes the round trip test combines; mode_linux_test.go adds Linux's own.
+var systemModes = []dl.Mode{dl.Now, dl.Lazy, dl.Global, dl.Local}
+
+func TestParseModeRoundTrip(t *testing.T) {
+	for set := 0; set < 1 << len(systemModes); set++ {
+		var m dl.Mode
+		for i, x := range systemModes {
+			if set & (1 << i) != 0 {
+				m |= x
+			}
+		}
+		if !m.Supported() || (m.Has(dl.Now) && m.Has(dl.Lazy)) || (dl.Local != 0 && m.Has(dl.Global) && m.Has(dl.Local)) {
+			continue
+		}
+		for _, m := range []dl.Mode{m, m | 1 << 20} {
+			got, err := dl.ParseMode(m.String())
+			if err != nil {
+				t.Errorf("ParseMode(%q): %v", m.String(), err)
+			} else if got != m {
+				t.Errorf("ParseMode(%q) = %v; want %v", m.String(), got, m)
+			}
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, tt := range []struct {
+		s	string
+		want	dl.Mode
+	}{
+		{"lazy|global", dl.Lazy | dl.Global},
+		{" NOW | Global ", dl.Now | dl.Global},
+		{"Now|0x100000", dl.Now | 1 << 20},
+		{"0", 0},
+	} {
+		got, err := dl.ParseMode(tt.s)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMode(%q) = %v, %v; want %v", tt.s, got, err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "Now|", "|Lazy", "Lazyy", "Now|Lazy"} {
+		if m, err := dl.ParseMode(s); err == nil {
+			t.Errorf("ParseMode(%q) = %v; want an error", s, m)
+		}
+	}
+	if _, err := dl.ParseMode("Now|Lazy"); !errors.Is(err, dl.ErrInvalidMode) {
+		t.Errorf("ParseMode(Now|Lazy) error = %v; want ErrInvalidMode", err)
+	}
+}
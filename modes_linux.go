@@ -0,0 +1,44 @@
+// 14 october 2026
+
+package dl
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// /* musl has no RTLD_DEEPBIND; give it a bit no libc uses so Open can refuse it */
+// #ifdef RTLD_DEEPBIND
+// #define dlDeepbind RTLD_DEEPBIND
+// #define dlNoDeepbind 0
+// #else
+// #define dlDeepbind 0x40000000
+// #define dlNoDeepbind 0x40000000
+// #endif
+import "C"
+
+// These modes are GNU extensions.
+// Not every C library for Linux has all of them; Open returns ErrUnsupported if given one the C library doesn't have, and Mode.Supported can check ahead of time.
+const (
+	// Nodelete keeps the library loaded even after it has been closed as many times as it was opened.
+	// Any later Open of the same library will return the same Module again, with its state intact.
+	Nodelete Mode = C.RTLD_NODELETE
+
+	// Noload does not load the library; instead, Open succeeds only if the library is already loaded, and returns another reference to it.
+	// This can be combined with Global to promote an already loaded library, or with Nodelete to make it stay loaded.
+	Noload Mode = C.RTLD_NOLOAD
+
+	// Deepbind makes the library prefer its own symbols (and those of its dependencies) over ones of the same name already loaded into the process.
+	// This is only available in glibc.
+	Deepbind Mode = C.dlDeepbind
+)
+
+func init() {
+	modeNames = append(modeNames,
+		modeName{ Nodelete, "Nodelete" },
+		modeName{ Noload, "Noload" },
+		modeName{ Deepbind, "Deepbind" })
+	features[FeatureNoload] = true
+	features[FeatureNodelete] = true
+	features[FeatureDeepbind] = Deepbind.Supported()
+}
+
+// unsupportedModes are the bits of Mode the C library doesn't have.
+const unsupportedModes Mode = C.dlNoDeepbind
@@ -0,0 +1,9 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// unsupportedModes are the bits of Mode the system doesn't have.
+// Every mode that exists on these systems is supported.
+const unsupportedModes Mode = 0
@@ -0,0 +1,220 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// modinfo is what the package knows about a Module opened through it.
+// dlopen() returns the same handle each time the same object is opened, so one modinfo covers all of them.
+type modinfo struct {
+	name	string		// as passed to the first Open; empty for OpenSelf
+	refs		int			// number of opens through this package not yet closed
+	sysrefs	int			// number of those that still hold a reference from the system; fewer than refs if opens are shared
+	seq		uint64		// orders modinfos by when they were first opened
+	site		string		// where the first Open was called from; only recorded while duplicate opens are forbidden
+	mode	Mode		// as passed to the first Open
+	time		time.Time		// when the first Open happened
+	stack	[]uintptr		// the stack of the first Open; only recorded while SetRecordOpenStacks(true) is in effect
+	symlock	sync.RWMutex			// Symbol only holds dllock for reading, so the cache needs its own lock
+	syms		map[string]unsafe.Pointer	// cache of successful Symbol lookups
+	cleanup	[]func()					// run once the last reference is closed
+	rules	NameRules				// see SetNameRules
+	closes	atomic.Uint64				// number of Closes so far; see SymbolTable
+}
+
+func (mi *modinfo) cached(name string) (unsafe.Pointer, bool) {
+	mi.symlock.RLock()
+	defer mi.symlock.RUnlock()
+	s, ok := mi.syms[name]
+	return s, ok
+}
+
+func (mi *modinfo) cache(name string, s unsafe.Pointer) {
+	mi.symlock.Lock()
+	defer mi.symlock.Unlock()
+	mi.syms[name] = s
+}
+
+// modules holds a modinfo for every Module currently open through this package.
+// Guarded by dllock, as is everything else in this file; only Symbol reads it without holding dllock for writing.
+var modules = make(map[Module]*modinfo)
+
+// closedModules holds the name of every Module whose last reference through this package was closed, until the same handle is opened again; see ErrClosed.
+var closedModules = make(map[Module]string)
+
+// nextSeq is the seq of the next new modinfo.
+var nextSeq uint64
+
+var forbidDuplicates = false
+
+// ErrAlreadyOpen is returned by Open, wrapped in a *DuplicateOpenError, if duplicate opens are forbidden and the library is already open.
+var ErrAlreadyOpen = errors.New("dl: library already open")
+
+// DuplicateOpenError describes a library opened a second time while SetForbidDuplicateOpen(true) is in effect.
+// errors.Is(err, ErrAlreadyOpen) is true for it.
+type DuplicateOpenError struct {
+	Name	string		// the name passed to the failed Open
+	Path		string		// the file both Opens resolved to, if known
+	Site		string		// file:line of the earlier Open, if known
+}
+
+func (e *DuplicateOpenError) Error() string {
+	s := fmt.Sprintf("dl: %s is already open", e.Name)
+	if e.Path != "" && e.Path != e.Name {
+		s += " (as " + e.Path + ")"
+	}
+	if e.Site != "" {
+		s += "; first opened at " + e.Site
+	}
+	return s
+}
+
+func (e *DuplicateOpenError) Unwrap() error {
+	return ErrAlreadyOpen
+}
+
+// SetForbidDuplicateOpen controls whether Open fails if the library it resolves to is already open through this package.
+// This is meant to catch accidental double loads during development; it is off by default.
+// While it is on, Open also records where each library was first opened from, so the error can point at it.
+// Libraries opened before the guard was turned on are still known to be open, but without the location.
+// OpenSelf is not affected.
+func SetForbidDuplicateOpen(on bool) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	forbidDuplicates = on
+}
+
+// opened records that m was opened under the given name and mode.
+// If guard is set and duplicates are forbidden, it returns a *DuplicateOpenError instead of recording anything if m is already open; the caller is responsible for undoing the dlopen().
+func opened(m Module, name string, mode Mode, guard bool) error {
+	mi := modules[m]
+	if mi != nil && guard && forbidDuplicates {
+		e := &DuplicateOpenError{
+			Name:	name,
+			Site:		mi.site,
+		}
+		if o, err := m.object(); err == nil {
+			e.Path = o.path
+		}
+		return e
+	}
+	if mi == nil {
+		mi = &modinfo{
+			name:	name,
+			mode:	mode,
+			time:		time.Now(),
+			seq:		nextSeq,
+			syms:	make(map[string]unsafe.Pointer),
+		}
+		nextSeq++
+		delete(closedModules, m)
+		if forbidDuplicates {
+			mi.site = callSite()
+		}
+		if recordStacks {
+			mi.stack = callers()
+		}
+		modules[m] = mi
+	}
+	mi.refs++
+	mi.sysrefs++
+	return nil
+}
+
+// closed records that m was closed.
+func closed(m Module) {
+	mi := modules[m]
+	if mi == nil {		// not opened by us
+		return
+	}
+	mi.closes.Add(1)
+	mi.refs--
+	if mi.refs == 0 {
+		forget(m, mi)
+	}
+}
+
+// forget drops the package's books on m, whose modinfo is mi.
+func forget(m Module, mi *modinfo) {
+	delete(modules, m)
+	closedModules[m] = mi.name
+	for _, f := range mi.cleanup {
+		f()
+	}
+}
+
+// onLastClose arranges for f to be called once the last reference to m opened through this package is closed.
+func onLastClose(m Module, f func()) {
+	mi := modules[m]
+	mi.cleanup = append(mi.cleanup, f)
+}
+
+// ErrClosed is returned, wrapped in an *Error, when a Module is used after it was closed, including when it is closed again.
+// Only Modules opened through this package can be recognized, and only until the system hands out the same handle for another open.
+var ErrClosed = errors.New("dl: library already closed")
+
+// closedError returns the error for doing op with m, or nil if m is not known to be closed.
+// The caller must hold dllock, for reading at least.
+func (m Module) closedError(op string, symbol string) error {
+	name, ok := closedModules[m]
+	if !ok {
+		return nil
+	}
+	what := name
+	if what == "" {
+		what = "the program"		// from OpenSelf
+	}
+	return &Error{
+		Op:		op,
+		Library:	name,
+		Symbol:	symbol,
+		Err:		ErrClosed,
+		Msg:		"dl: " + what + " is already closed",
+	}
+}
+
+// callSite returns the file:line of the first caller outside this package.
+func callSite() string {
+	pc := make([]uintptr, 16)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "github.com/andlabs/dl.") {
+			return fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// InvalidateCache forgets the given symbols, or all symbols if none are given, from the cache Symbol keeps for m.
+// The cache is emptied when the last reference to m is closed, so the only reason to call this is if something outside this package unloaded and reloaded the library behind its back.
+func (m Module) InvalidateCache(names ...string) {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	mi := modules[m]
+	if mi == nil {
+		return
+	}
+	mi.symlock.Lock()
+	defer mi.symlock.Unlock()
+	if len(names) == 0 {
+		mi.syms = make(map[string]unsafe.Pointer)
+		return
+	}
+	for _, name := range names {
+		delete(mi.syms, name)
+	}
+}
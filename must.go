@@ -0,0 +1,28 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// MustOpen is like Open, but panics if the library cannot be opened.
+// It is meant for initializing package-level variables and for init functions, where there is nothing better to do with the error.
+func MustOpen(name string, mode Mode) Module {
+	m, err := Open(name, mode)
+	if err != nil {
+		panic(fmt.Errorf("dl: MustOpen(%q): %w", name, err))
+	}
+	return m
+}
+
+// MustSymbol is like Symbol, but panics if the symbol cannot be found.
+// A symbol whose value is nil is returned as nil, as with Symbol.
+func (m Module) MustSymbol(name string) unsafe.Pointer {
+	s, err := m.Symbol(name)
+	if err != nil {
+		panic(fmt.Errorf("dl: MustSymbol(%q): %w", name, err))
+	}
+	return s
+}
@@ -0,0 +1,90 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// NameRule turns a symbol name into one to look up instead; see NameRules.
+type NameRule func(name string) string
+
+// Unchanged is the NameRule that looks up the name as given.
+func Unchanged(name string) string {
+	return name
+}
+
+// Prefix returns a NameRule that puts p in front of the name; Prefix("_") gives the name the way some compilers decorate C names, and Prefix("mylib_") finds a library's namespaced functions.
+func Prefix(p string) NameRule {
+	return func(name string) string {
+		return p + name
+	}
+}
+
+// Suffix returns a NameRule that puts s after the name; Suffix("_") gives the name the way Fortran compilers usually decorate it.
+func Suffix(s string) NameRule {
+	return func(name string) string {
+		return name + s
+	}
+}
+
+// NameRules is an ordered list of ways to turn the name of a symbol as a program knows it into the name a library has for it, for bindings that have to work with libraries built by different toolchains:
+// 	rules := dl.NameRules{dl.Unchanged, dl.Prefix("_"), dl.Suffix("_")}
+// The first name that is found wins; include Unchanged where the name as given should be tried.
+type NameRules []NameRule
+
+// Names returns the names r gives for name, in order, leaving out repeats; pass them to Module.SymbolAny for a one-off lookup.
+func (r NameRules) Names(name string) []string {
+	names := make([]string, 0, len(r))
+	seen := make(map[string]bool, len(r))
+	for _, rule := range r {
+		n := rule(name)
+		if !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// SetNameRules makes every later Symbol lookup in m through this package (including those by Symbols, SymbolAny, Bind, and the rest) try the names r gives, in order, instead of the name asked for; pass nil to go back to the name as given.
+// Lookups are cached under the name asked for, and the cache is emptied, since what a name finds may have changed.
+// The rules last until m's last reference is closed; modules not opened through this package can't have any.
+func (m Module) SetNameRules(r NameRules) error {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if err := m.closedError("info", ""); err != nil {
+		return err
+	}
+	mi := modules[m]
+	if mi == nil {
+		return errors.New("dl: name rules can only be set on a Module opened through this package")
+	}
+	mi.rules = r
+	mi.symlock.Lock()
+	mi.syms = make(map[string]unsafe.Pointer)
+	mi.symlock.Unlock()
+	return nil
+}
+
+// ruleSymbol looks up name in m using rules, returning the error from the first name tried if none are found.
+// The caller must hold dllock, for reading at least.
+func (m Module) ruleSymbol(name string, rules NameRules) (unsafe.Pointer, error) {
+	var first error
+
+	for _, n := range rules.Names(name) {
+		s, err := m.syssymbol(n)
+		if err == nil {
+			return s, nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	if first == nil {
+		return nil, errors.New("dl: the Module's name rules give no names for " + name)
+	}
+	return nil, first
+}
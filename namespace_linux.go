@@ -0,0 +1,77 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"time"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// #ifdef __GLIBC__
+// #define haveDlmopen 1
+// #else
+// #define haveDlmopen 0
+// typedef long Lmid_t;
+// #define dlmopen(l, n, f) NULL
+// #endif
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// static void *dlmopenerr(Lmid_t lmid, const char *name, int mode, char **err)
+// {
+// 	void *h;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	h = dlmopen(lmid, name, mode);
+// 	if (h == NULL)
+// 		*err = dlerrordup();
+// 	return h;
+// }
+import "C"
+
+func init() {
+	features[FeatureDlmopen] = C.haveDlmopen != 0
+}
+
+// OpenNamespace is like Open, but opens name into the given link-map namespace instead of the base one.
+// This is dlmopen(), and lets two libraries that would otherwise conflict (for instance, two versions of the same library) coexist in one process.
+//
+// Pass LMNew to create a new namespace holding name and its dependencies; then use the Lmid field of the new Module's Info to open more libraries into that same namespace.
+// Note that Global has no effect across namespaces; each namespace has its own global scope.
+//
+// Only glibc has dlmopen(); with other C libraries, OpenNamespace returns ErrUnsupported.
+func OpenNamespace(lmid Lmid, name string, mode Mode) (Module, error) {
+	if C.haveDlmopen == 0 {
+		return 0, ErrUnsupported
+	}
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	start := time.Now()
+	path, err := loadPath(name)		// as open() does, so the same name loads the same file from both
+	if err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	if err := checkPolicy(name, path); err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	if validate {
+		if err := validateOpen(path); err != nil {
+			return finishOpen(name, mode, start, 0, err)
+		}
+	}
+	var e *C.char
+	cname := C.CString(path)
+	defer C.free(unsafe.Pointer(cname))
+	m := C.dlmopenerr(C.Lmid_t(lmid), cname, C.int(mode), &e)
+	if m == nil {
+		return finishOpen(name, mode, start, 0, openError(name, path, mode, takeError(e)))
+	}
+	return finishOpen(name, mode, start, Module(m), nil)
+}
@@ -0,0 +1,11 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// OpenNamespace is like Open, but opens name into the given link-map namespace instead of the base one.
+// This needs dlmopen(), which this system doesn't have, so it returns ErrUnsupported.
+func OpenNamespace(lmid Lmid, name string, mode Mode) (Module, error) {
+	return 0, ErrUnsupported
+}
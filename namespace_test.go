@@ -0,0 +1,60 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestOpenNamespaceResolver(t *testing.T) {
+	if !dl.Supports(dl.FeatureDlmopen) {
+		t.Skip("no dlmopen()")
+	}
+	lib := dltest.Build(t, symbolSource)
+	dl.SetResolver(func(name string) (string, error) {
+		if name == "libnamespacetest.so" {
+			return lib, nil
+		}
+		return name, nil
+	})
+	defer dl.SetResolver(nil)
+
+	m, err := dl.OpenNamespace(dl.LMNew, "libnamespacetest.so", dl.Now)
+	if err != nil {
+		t.Fatalf("OpenNamespace of a name only the Resolver knows: %v", err)
+	}
+	defer m.Close()
+	if path, err := m.Path(); err != nil || path != lib {
+		t.Errorf("Path = %q, %v; want %q", path, err, lib)
+	}
+}
+
+func TestOpenNamespaceValidate(t *testing.T) {
+	if !dl.Supports(dl.FeatureDlmopen) {
+		t.Skip("no dlmopen()")
+	}
+	garbage := filepath.Join(t.TempDir(), "libgarbage.so")
+	if err := os.WriteFile(garbage, []byte("this is not a library, just some text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dl.SetValidate(true)
+	defer dl.SetValidate(false)
+
+	m, err := dl.OpenNamespace(dl.LMNew, garbage, dl.Now)
+	if err == nil {
+		m.Close()
+		t.Fatalf("OpenNamespace of a text file succeeded")
+	}
+	var fe *dl.FormatError
+	if !errors.As(err, &fe) {
+		t.Errorf("OpenNamespace of a text file error = %v; want a *FormatError from SetValidate", err)
+	}
+}
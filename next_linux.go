@@ -0,0 +1,67 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <link.h>
+// #ifdef __GLIBC__
+// #define haveGlibc 1
+// /* returns the link map entry of the object containing addr */
+// static struct link_map *owner(void *addr)
+// {
+// 	Dl_info info;
+// 	struct link_map *lm;
+//
+// 	if (dladdr1(addr, &info, (void **) (&lm), RTLD_DL_LINKMAP) == 0)
+// 		return NULL;
+// 	return lm;
+// }
+// #else
+// #define haveGlibc 0
+// static struct link_map *owner(void *addr) { return NULL; }
+// #endif
+import "C"
+
+// NextSymbol looks up the given named symbol in the objects loaded after m, skipping m itself.
+// This is RTLD_NEXT, but relative to m instead of to the caller; it lets a shim loaded with this package find the definition it is interposing on.
+// Only the objects themselves are considered, not their dependencies, so the result is the first definition after m in load order.
+// NextSymbol relies on glibc's link map behavior; it returns ErrUnsupported with other C libraries.
+func (m Module) NextSymbol(name string) (unsafe.Pointer, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, func() (unsafe.Pointer, error) {
+			return m.NextSymbol(name)
+		})
+	}
+	if C.haveGlibc == 0 {
+		return nil, ErrUnsupported
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	lm, err := m.linkmap()
+	if err != nil {
+		return nil, err
+	}
+	for l := lm.l_next; l != nil; l = l.l_next {
+		// in glibc, a link map entry is also a handle
+		// dlsym() will search l's dependencies too, so make sure the definition is actually in l
+		symbol, ok := Module(unsafe.Pointer(l)).dlsymok(name)
+		if ok && symbol != nil && C.owner(symbol) == l {
+			return symbol, nil
+		}
+	}
+	return nil, &Error{
+		Op:		"symbol",
+		Library:	m.libraryName(),
+		Symbol:	name,
+		Err:		ErrSymbolNotFound,
+		Msg:		fmt.Sprintf("dl: no definition of %s after %s", name, C.GoString(lm.l_name)),
+	}
+}
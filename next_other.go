@@ -0,0 +1,15 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// NextSymbol looks up the given named symbol in the objects loaded after m, skipping m itself.
+// It relies on glibc's link map behavior, so it returns ErrUnsupported on this system.
+func (m Module) NextSymbol(name string) (unsafe.Pointer, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,53 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestNextSymbol(t *testing.T) {
+	first := dltest.Build(t, "int dltest_which(void) { return 1; }\n")
+	second := dltest.Build(t, "int dltest_which(void) { return 2; }\n")
+	m1, err := dl.Open(first, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", first, err)
+	}
+	defer m1.Close()
+	m2, err := dl.Open(second, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", second, err)
+	}
+	defer m2.Close()
+
+	next, err := m1.NextSymbol("dltest_which")
+	if errors.Is(err, dl.ErrUnsupported) {
+		t.Skipf("NextSymbol: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("NextSymbol: %v", err)
+	}
+	own, err := m1.Symbol("dltest_which")
+	if err != nil {
+		t.Fatalf("Symbol in the first library: %v", err)
+	}
+	want, err := m2.Symbol("dltest_which")
+	if err != nil {
+		t.Fatalf("Symbol in the second library: %v", err)
+	}
+	if next == own {
+		t.Errorf("NextSymbol returned the first library's own definition")
+	}
+	if next != want {
+		t.Errorf("NextSymbol = %p; want the second library's definition %p", next, want)
+	}
+	if _, err := m2.NextSymbol("dltest_which"); !errors.Is(err, dl.ErrSymbolNotFound) {
+		t.Errorf("NextSymbol past the last definition error = %v; want ErrSymbolNotFound", err)
+	}
+}
@@ -0,0 +1,9 @@
+// 14 october 2026
+
+package dl
+
+// object describes the file backing a loaded Module.
+type object struct {
+	path	string
+	bias	uintptr		// added to an address in the file to get the address in memory
+}
@@ -0,0 +1,10 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// TODO dladdr() on a symbol could get us the path on other systems, but there is no one symbol every object is guaranteed to have
+func (m Module) object() (*object, error) {
+	return nil, ErrUnsupported
+}
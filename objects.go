@@ -0,0 +1,40 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+)
+
+// LoadedObject describes an object mapped into the process, as returned by LoadedObjects.
+type LoadedObject struct {
+	// Name is the name the dynamic linker has for the object, which is normally its full path.
+	// It is empty for the main program on ELF systems.
+	Name		string
+
+	// Base is the difference between addresses in the file and addresses in memory; for shared objects, this is the address the object was loaded at.
+	Base			uintptr
+
+	// Segments are the object's ELF program headers, with their addresses in memory.
+	// They are only available on ELF systems; on OS X, Segments is nil.
+	Segments		[]Segment
+}
+
+// Segment is one of an ELF object's program headers.
+type Segment struct {
+	Type		elf.ProgType	// such as elf.PT_LOAD or elf.PT_DYNAMIC
+	Flags	elf.ProgFlag	// permissions; elf.PF_R, elf.PF_W, and elf.PF_X
+	Addr		uintptr		// where the segment is in memory
+	FileSize	uint64		// how much of the segment comes from the file
+	MemSize	uint64		// how big the segment is in memory
+}
+
+// LoadedObjects lists every object currently mapped into the process, in the dynamic linker's order, whether or not it was opened through this package.
+// Use it to find out what is already loaded; for instance, to notice that a plugin brings its own copy of a library the program already has.
+// This is dl_iterate_phdr() on Linux and the BSDs and the _dyld_ functions on OS X; other systems return ErrUnsupported.
+// Other cgo code can load and unload objects at any time, so the list can be out of date by the time it's returned.
+func LoadedObjects() ([]LoadedObject, error) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	return loadedObjects()
+}
@@ -0,0 +1,30 @@
+// 14 october 2026
+
+package dl
+
+// #include <mach-o/dyld.h>
+// #include <stdint.h>
+import "C"
+
+func init() {
+	features[FeatureLoadedObjects] = true
+}
+
+// loadedObjects does the work of LoadedObjects.
+// dyld can add images between calls, and remove them too; an image that goes away while we're looking has a NULL name.
+// The caller must hold dllock.
+func loadedObjects() ([]LoadedObject, error) {
+	n := C._dyld_image_count()
+	l := make([]LoadedObject, 0, n)
+	for i := C.uint32_t(0); i < n; i++ {
+		name := C._dyld_get_image_name(i)
+		if name == nil {
+			continue
+		}
+		l = append(l, LoadedObject{
+			Name:	C.GoString(name),
+			Base:	uintptr(C._dyld_get_image_vmaddr_slide(i)),
+		})
+	}
+	return l, nil
+}
@@ -0,0 +1,9 @@
+// 14 october 2026
+
+//go:build !linux && !freebsd && !netbsd && !openbsd && !darwin
+
+package dl
+
+func loadedObjects() ([]LoadedObject, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,103 @@
+// 14 october 2026
+
+//go:build linux || freebsd || netbsd || openbsd
+
+package dl
+
+import (
+	"debug/elf"
+	"errors"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <link.h>
+// #include <stdlib.h>
+// #include <string.h>
+// struct objects {
+// 	struct dl_phdr_info *o;
+// 	size_t n;
+// 	size_t cap;
+// 	int failed;
+// };
+// /* the strings and program headers belong to the dynamic linker, so copy them in case an object is unloaded before Go is done */
+// static int collect(struct dl_phdr_info *info, size_t size, void *data)
+// {
+// 	struct objects *objs = (struct objects *) data;
+// 	struct dl_phdr_info *o;
+//
+// 	if (objs->n == objs->cap) {
+// 		size_t cap = objs->cap * 2 + 16;
+// 		o = realloc(objs->o, cap * sizeof (struct dl_phdr_info));
+// 		if (o == NULL) {
+// 			objs->failed = 1;
+// 			return 1;
+// 		}
+// 		objs->o = o;
+// 		objs->cap = cap;
+// 	}
+// 	o = &(objs->o[objs->n]);
+// 	memset(o, 0, sizeof (struct dl_phdr_info));
+// 	o->dlpi_addr = info->dlpi_addr;
+// 	o->dlpi_name = strdup(info->dlpi_name != NULL ? info->dlpi_name : "");
+// 	o->dlpi_phnum = info->dlpi_phnum;
+// 	o->dlpi_phdr = malloc(info->dlpi_phnum * sizeof (ElfW(Phdr)));
+// 	objs->n++;
+// 	if (o->dlpi_name == NULL || o->dlpi_phdr == NULL) {
+// 		objs->failed = 1;
+// 		return 1;
+// 	}
+// 	memcpy((void *) (o->dlpi_phdr), info->dlpi_phdr, info->dlpi_phnum * sizeof (ElfW(Phdr)));
+// 	return 0;
+// }
+// static void iterate(struct objects *objs)
+// {
+// 	dl_iterate_phdr(collect, objs);
+// }
+// static void freeobjects(struct objects *objs)
+// {
+// 	size_t i;
+//
+// 	for (i = 0; i < objs->n; i++) {
+// 		free((void *) (objs->o[i].dlpi_name));
+// 		free((void *) (objs->o[i].dlpi_phdr));
+// 	}
+// 	free(objs->o);
+// }
+import "C"
+
+func init() {
+	features[FeatureLoadedObjects] = true
+}
+
+var errIterateFailed = errors.New("dl: out of memory enumerating loaded objects")
+
+// loadedObjects does the work of LoadedObjects.
+// The caller must hold dllock.
+func loadedObjects() ([]LoadedObject, error) {
+	var objs C.struct_objects
+
+	C.iterate(&objs)
+	defer C.freeobjects(&objs)
+	if objs.failed != 0 {
+		return nil, errIterateFailed
+	}
+	infos := unsafe.Slice(objs.o, objs.n)
+	l := make([]LoadedObject, len(infos))
+	for i, info := range infos {
+		l[i].Name = C.GoString(info.dlpi_name)
+		l[i].Base = uintptr(info.dlpi_addr)
+		phdrs := unsafe.Slice(info.dlpi_phdr, info.dlpi_phnum)
+		l[i].Segments = make([]Segment, len(phdrs))
+		for j, p := range phdrs {
+			l[i].Segments[j] = Segment{
+				Type:		elf.ProgType(p.p_type),
+				Flags:	elf.ProgFlag(p.p_flags),
+				Addr:		l[i].Base + uintptr(p.p_vaddr),
+				FileSize:	uint64(p.p_filesz),
+				MemSize:	uint64(p.p_memsz),
+			}
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,98 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// OpenAt opens the library name inside root, and refuses to open anything outside it: absolute names, names with .. that lead out, and symbolic links that point out are all errors.
+// Use it to load plugins named by users from a directory of their own without letting the names wander off.
+//
+// The library is loaded by its real absolute path, so its $ORIGIN is its directory as usual.
+// Between checking the path and loading it, the file is checked to still be the one that was checked, but a directory along the way can still be swapped in between; keep root writable only by people you trust as much as the program.
+// Libraries the plugin depends on are found the usual way, not inside root.
+// As with Open, the Policy is checked, and SetValidate's check is made, before anything is loaded.
+//
+// OpenAt takes an *os.Root rather than an *os.File or a directory file descriptor: dlopen() only takes a path, so the checks against escaping have to be made before it, and os.Root already makes them, on every system, in a way that can't be fooled by symbolic links.
+// To use a directory you already have open, pass os.OpenRoot(dir.Name()).
+func OpenAt(root *os.Root, name string, mode Mode) (Module, error) {
+	if err := mode.check(); err != nil {
+		return 0, err
+	}
+	path, f, err := inRoot(root, name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	start := time.Now()
+	if err := checkPolicy(name, path); err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	if validate {
+		if err := validateOpen(path); err != nil {
+			return finishOpen(name, mode, start, 0, err)
+		}
+	}
+	if err := sameFile(f, path); err != nil {
+		return finishOpen(name, mode, start, 0, err)
+	}
+	m, err := sysopen(path, mode)
+	if err != nil {
+		err = openError(name, path, mode, err)
+	}
+	return finishOpen(name, mode, start, m, err)
+}
+
+// inRoot returns the real absolute path of name in root, and the file itself, opened through root so that os.Root checks it doesn't escape.
+func inRoot(root *os.Root, name string) (string, *os.File, error) {
+	if filepath.IsAbs(name) {
+		return "", nil, fmt.Errorf("dl: %s is an absolute path", name)
+	}
+	f, err := root.Open(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("dl: %w", err)
+	}
+	dir, err := filepath.EvalSymlinks(root.Name())
+	if err == nil {
+		dir, err = filepath.Abs(dir)
+	}
+	var path string
+	if err == nil {
+		path, err = filepath.EvalSymlinks(filepath.Join(dir, name))
+	}
+	if err != nil {
+		f.Close()
+		return "", nil, err
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".." + string(filepath.Separator)) {
+		f.Close()
+		return "", nil, fmt.Errorf("dl: %s leads out of %s", name, root.Name())
+	}
+	return path, f, nil
+}
+
+// sameFile makes sure path is still the file f.
+func sameFile(f *os.File, path string) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	pi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !os.SameFile(fi, pi) {
+		return fmt.Errorf("dl: %s changed while being opened", path)
+	}
+	return nil
+}
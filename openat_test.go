@@ -0,0 +1,56 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestOpenAt(t *testing.T) {
+	dir := t.TempDir()
+	copyFile(t, dltest.Build(t, symbolSource), filepath.Join(dir, "libplugin.so"))
+	if err := os.WriteFile(filepath.Join(dir, "libgarbage.so"), []byte("this is not a library, just some text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/usr/lib", filepath.Join(dir, "out")); err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+
+	m, err := dl.OpenAt(root, "libplugin.so", dl.Now)
+	if err != nil {
+		t.Fatalf("OpenAt of a library in root: %v", err)
+	}
+	m.Close()
+
+	for _, name := range []string{"../libplugin.so", "/usr/lib/libc.so", "out/libc.so"} {
+		if m, err := dl.OpenAt(root, name, dl.Now); err == nil {
+			m.Close()
+			t.Errorf("OpenAt(%q) outside root succeeded", name)
+		}
+	}
+
+	dl.SetValidate(true)
+	defer dl.SetValidate(false)
+	m, err = dl.OpenAt(root, "libgarbage.so", dl.Now)
+	if err == nil {
+		m.Close()
+		t.Fatalf("OpenAt of a text file succeeded")
+	}
+	var fe *dl.FormatError
+	if !errors.As(err, &fe) {
+		t.Errorf("OpenAt of a text file error = %v; want a *FormatError from SetValidate", err)
+	}
+}
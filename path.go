@@ -0,0 +1,31 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+)
+
+// Path returns the file the dynamic linker actually loaded for m, so you know which of the many files with that name you got.
+// For the main program (see OpenSelf), this is the program's executable.
+// For a Module opened with OpenBytes or OpenFS, it is the name of the in-memory or temporary copy.
+//
+// On Linux this comes from dlinfo() and on Windows from GetModuleFileName(), so it is always available.
+// Elsewhere it is worked out by passing a symbol already looked up in m through dladdr(), so it fails until Symbol has found something in m itself (rather than in one of its dependencies).
+func (m Module) Path() (string, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.Path)
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if m.pseudo() {
+		return "", errPseudoPath
+	}
+	if err := m.closedError("info", ""); err != nil {
+		return "", err
+	}
+	return m.path()
+}
+
+var errPseudoPath = errors.New("dl: a pseudo-Module has no file")
@@ -0,0 +1,19 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"os"
+)
+
+// The caller must hold dllock.
+func (m Module) path() (string, error) {
+	o, err := m.object()
+	if err != nil {
+		return "", err
+	}
+	if o.path == "/proc/self/exe" {
+		return os.Executable()
+	}
+	return o.path, nil
+}
@@ -0,0 +1,53 @@
+// 14 october 2026
+
+//go:build unix && !linux
+
+package dl
+
+import (
+	"errors"
+)
+
+// #include <dlfcn.h>
+// /* RTLD_NOLOAD isn't in the SUS, but every system this file is built on has it */
+// static void *reopen(const char *name) { return dlopen(name, RTLD_LAZY | RTLD_NOLOAD); }
+import "C"
+
+var errNoPath = errors.New("dl: the library's path is not known until a symbol defined in it has been looked up")
+
+// path tries each symbol cached for m until dladdr() names a file that, reopened, is m itself; the others came from m's dependencies.
+// The caller must hold dllock.
+func (m Module) path() (string, error) {
+	info, err := m.ownSymbol()
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(info.dli_fname), nil
+}
+
+// ownSymbol finds a symbol cached for m that is defined in m itself, and returns what dladdr() says about it.
+// The caller must hold dllock.
+func (m Module) ownSymbol() (*C.Dl_info, error) {
+	var info C.Dl_info
+
+	mi := modules[m]
+	if mi == nil {
+		return nil, errNoPath
+	}
+	mi.symlock.Lock()
+	defer mi.symlock.Unlock()
+	for _, s := range mi.syms {
+		if s == nil || C.dladdr(s, &info) == 0 {
+			continue
+		}
+		h := C.reopen(info.dli_fname)
+		if h == nil {
+			continue
+		}
+		C.dlclose(h)		// drop the reference reopen() took
+		if Module(h) == m {
+			return &info, nil
+		}
+	}
+	return nil, errNoPath
+}
@@ -0,0 +1,125 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// Trampoline calls the C function fn, which takes no arguments, on behalf of a Plugin.
+// This is the one piece of cgo a Plugin needs from you, since only you know what your plugins' functions return:
+// 	// int callinit(void *p)
+// 	// {
+// 	// 	int (*f)(void);
+// 	//
+// 	// 	*((void **) (&f)) = p;
+// 	// 	return (*f)();
+// 	// }
+// 	import "C"
+// 	func call(fn unsafe.Pointer) error {
+// 		if C.callinit(fn) != 0 {
+// 			return errors.New("plugin failed")
+// 		}
+// 		return nil
+// 	}
+type Trampoline func(fn unsafe.Pointer) error
+
+// DefaultPluginInit is the name of the initialization function OpenPlugin calls if not told otherwise.
+const DefaultPluginInit = "plugin_init"
+
+// Plugin is a Module that follows a simple lifecycle convention: it is initialized by calling a well-known function when opened, and torn down by calling its registered teardown functions, in the order they were registered, when closed.
+// Plugins are safe for concurrent use.
+type Plugin struct {
+	Module	Module
+
+	lock		sync.Mutex
+	call		Trampoline
+	teardown	[]unsafe.Pointer
+	names	[]string
+	closed	bool
+}
+
+// OpenPlugin opens name with the given mode, then looks up the function named init (or DefaultPluginInit if init is empty) and calls it with call.
+// If the function does not exist or call returns an error, the library is closed again and the error is returned.
+func OpenPlugin(name string, mode Mode, init string, call Trampoline) (*Plugin, error) {
+	if call == nil {
+		return nil, errors.New("dl: OpenPlugin needs a Trampoline")
+	}
+	if init == "" {
+		init = DefaultPluginInit
+	}
+	m, err := Open(name, mode)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := m.Symbol(init)
+	if err == nil && fn == nil {
+		err = fmt.Errorf("dl: plugin %s has a nil %s", name, init)
+	}
+	if err == nil {
+		err = call(fn)
+		if err != nil {
+			err = fmt.Errorf("dl: %s of plugin %s failed: %w", init, name, err)
+		}
+	}
+	if err != nil {
+		m.Close()
+		return nil, err
+	}
+	return &Plugin{
+		Module:	m,
+		call:		call,
+	}, nil
+}
+
+// AddTeardown registers the named functions to be called, after any already registered, when p is closed.
+// The functions are looked up now; if any of them cannot be found, none are registered.
+func (p *Plugin) AddTeardown(names ...string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return errors.New("dl: AddTeardown on closed Plugin")
+	}
+	fns := make([]unsafe.Pointer, len(names))
+	for i, name := range names {
+		fn, err := p.Module.Symbol(name)
+		if err != nil {
+			return err
+		}
+		if fn == nil {
+			return fmt.Errorf("dl: teardown function %s is nil", name)
+		}
+		fns[i] = fn
+	}
+	p.teardown = append(p.teardown, fns...)
+	p.names = append(p.names, names...)
+	return nil
+}
+
+// Close calls each of p's teardown functions in order, then closes p's Module.
+// Every teardown function is called even if earlier ones fail; the returned error lists everything that went wrong.
+// Closing a Plugin that is already closed does nothing.
+func (p *Plugin) Close() error {
+	var errs []error
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	for i, fn := range p.teardown {
+		if err := p.call(fn); err != nil {
+			errs = append(errs, fmt.Errorf("dl: teardown function %s failed: %w", p.names[i], err))
+		}
+	}
+	if err := p.Module.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
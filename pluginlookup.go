@@ -0,0 +1,40 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"plugin"
+)
+
+// Lookuper is the method a *plugin.Plugin from the standard library and a NativePlugin have in common, so code can load Go plugins and native libraries behind the same interface.
+type Lookuper interface {
+	Lookup(name string) (plugin.Symbol, error)
+}
+
+var _ Lookuper = (*plugin.Plugin)(nil)
+var _ Lookuper = NativePlugin{}
+
+// NativePlugin makes a Module look like a *plugin.Plugin, for code that wants to switch between Go plugins and native libraries without changing its call sites.
+// Its Lookup returns the same thing Module.StrictSymbol does, as an unsafe.Pointer in the plugin.Symbol, where a Go plugin would give a pointer to a Go variable or a Go func; only the code that uses the symbol needs to know which kind of plugin it came from.
+type NativePlugin struct {
+	Module	Module
+}
+
+// OpenNativePlugin opens the library at path the way plugin.Open opens a Go plugin, with its symbols resolved right away and made available to libraries loaded afterward (Now|Global), and returns it as a NativePlugin.
+// Unlike plugin.Open, the library can be closed again, through the Module.
+func OpenNativePlugin(path string) (NativePlugin, error) {
+	m, err := Open(path, Now | Global)
+	if err != nil {
+		return NativePlugin{}, err
+	}
+	return NativePlugin{ Module: m }, nil
+}
+
+// Lookup looks up the named symbol in the library, which must have a non-nil address (as with StrictSymbol, since a Go plugin never returns a nil symbol either), and returns it as an unsafe.Pointer.
+func (p NativePlugin) Lookup(name string) (plugin.Symbol, error) {
+	s, err := p.Module.StrictSymbol(name)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,75 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Policy decides whether a library may be loaded.
+// name is what was passed to Open; path is the file that will be loaded, which is absolute if it could be worked out (see SetPolicy) and name otherwise.
+// Return nil to allow the load.
+type Policy func(name string, path string) error
+
+// policy is consulted by everything that loads a file; guarded by dllock.
+var policy Policy
+
+// ErrDenied is returned, wrapped together with the Policy's error, when a Policy refuses a load.
+var ErrDenied = errors.New("dl: load denied by policy")
+
+// SetPolicy makes every function in this package that loads a library (except OpenSelf) ask p first.
+// That includes the load of libffi the first time a Func is made; if p refuses it, NewFunc keeps failing even after the Policy changes.
+// Use it to only allow libraries from certain directories (see AllowDirs), or only ones with a known checksum.
+// Pass nil to allow everything again.
+//
+// To give p a path it can check, names are resolved first — by the Resolver if there is one (see SetResolver), or by Resolve otherwise — and the resolved path is what gets loaded, so the file p approved is the file loaded.
+// Where Resolve isn't available (Windows and OS X), or can't find the library, p gets the name as given; a strict Policy should refuse paths that aren't absolute.
+// For OpenBytes and OpenFS, path is the name of the copy; for OpenFd, it is the descriptor's name.
+// Note that p only sees the library itself, not the libraries it depends on; the dynamic linker loads those on its own.
+//
+// p is called with the package's lock held, so it must not call anything else in this package.
+func SetPolicy(p Policy) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	policy = p
+}
+
+// checkPolicy asks the Policy, if any, about loading path, which was asked for as name.
+// The caller must hold dllock.
+func checkPolicy(name string, path string) error {
+	if policy == nil {
+		return nil
+	}
+	if err := policy(name, path); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrDenied, name, err)
+	}
+	return nil
+}
+
+// AllowDirs returns a Policy that only allows libraries in the given directories or their subdirectories.
+// Symbolic links are followed, both in the directories and in the path of the library, so a link can't lead outside.
+func AllowDirs(dirs ...string) Policy {
+	return func(name string, path string) error {
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("%s is not an absolute path", path)		// no dl: prefix; checkPolicy() adds one
+		}
+		real, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return err
+		}
+		for _, dir := range dirs {
+			d, err := filepath.EvalSymlinks(dir)
+			if err != nil {
+				continue
+			}
+			rel, err := filepath.Rel(d, real)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".." + string(filepath.Separator)) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s is not in an allowed directory", real)
+	}
+}
@@ -0,0 +1,108 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestAllowDirs(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	allowed, other := t.TempDir(), t.TempDir()
+	copyFile(t, lib, filepath.Join(allowed, "libpolicytest.so"))
+	copyFile(t, lib, filepath.Join(other, "libpolicytest.so"))
+	if err := os.Symlink(filepath.Join(other, "libpolicytest.so"), filepath.Join(allowed, "libescape.so")); err != nil {
+		t.Fatal(err)
+	}
+	dl.SetPolicy(dl.AllowDirs(allowed))
+	defer dl.SetPolicy(nil)
+
+	m, err := dl.Open(filepath.Join(allowed, "libpolicytest.so"), dl.Now)
+	if err != nil {
+		t.Fatalf("Open of a library in an allowed directory: %v", err)
+	}
+	m.Close()
+	for _, path := range []string{
+		filepath.Join(other, "libpolicytest.so"),
+		filepath.Join(allowed, "libescape.so"),		// a symbolic link to outside
+		filepath.Join(allowed, "..", filepath.Base(other), "libpolicytest.so"),
+	} {
+		m, err := dl.Open(path, dl.Now)
+		if err == nil {
+			m.Close()
+			t.Errorf("Open(%q) outside the allowed directory succeeded", path)
+			continue
+		}
+		if !errors.Is(err, dl.ErrDenied) {
+			t.Errorf("Open(%q) error = %v; want ErrDenied", path, err)
+		}
+	}
+	if loaded, err := dl.IsLoaded(filepath.Join(other, "libpolicytest.so")); err == nil && loaded {
+		t.Errorf("a denied library was loaded anyway")
+	}
+}
+
+// The Policy must see the path that will actually be loaded: the Resolver's, if there is one, and Resolve's otherwise.
+func TestPolicyAfterResolver(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	dir := t.TempDir()
+	copyFile(t, lib, filepath.Join(dir, "libpolicytest.so"))
+	t.Setenv("LD_LIBRARY_PATH", dir)
+
+	type check struct {
+		name	string
+		path	string
+	}
+	var checks []check
+	dl.SetPolicy(func(name string, path string) error {
+		checks = append(checks, check{name, path})
+		return nil
+	})
+	defer dl.SetPolicy(nil)
+
+	m, err := dl.Open("libpolicytest.so", dl.Now)
+	if err != nil {
+		t.Fatalf("Open with Resolve: %v", err)
+	}
+	m.Close()
+	if want := (check{"libpolicytest.so", filepath.Join(dir, "libpolicytest.so")}); len(checks) != 1 || checks[0] != want {
+		t.Errorf("Policy was asked %+v; want %+v", checks, want)
+	}
+
+	checks = nil
+	dl.SetResolver(func(name string) (string, error) {
+		return lib, nil
+	})
+	defer dl.SetResolver(nil)
+	m, err = dl.Open("libpolicytest.so", dl.Now)
+	if err != nil {
+		t.Fatalf("Open with a Resolver: %v", err)
+	}
+	path, _ := m.Path()
+	m.Close()
+	if want := (check{"libpolicytest.so", lib}); len(checks) != 1 || checks[0] != want {
+		t.Errorf("Policy was asked %+v; want the Resolver's path, %+v", checks, want)
+	}
+	if path != lib {
+		t.Errorf("Path = %q; want the path the Policy approved, %q", path, lib)
+	}
+
+	denied := errors.New("denied by the test")
+	dl.SetPolicy(func(name string, path string) error {
+		return denied
+	})
+	if m, err := dl.Open("libpolicytest.so", dl.Now); !errors.Is(err, dl.ErrDenied) || !errors.Is(err, denied) {
+		if err == nil {
+			m.Close()
+		}
+		t.Errorf("Open denied by the Policy error = %v; want ErrDenied and the Policy's error", err)
+	}
+}
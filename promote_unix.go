@@ -0,0 +1,70 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// /* RTLD_NOLOAD isn't in the SUS, but SUS systems without it are hard to find */
+// #ifdef RTLD_NOLOAD
+// #define havePromote 1
+// #define promoteMode (RTLD_LAZY | RTLD_GLOBAL | RTLD_NOLOAD)
+// #else
+// #define havePromote 0
+// #define promoteMode 0
+// #endif
+// extern void *dlopenerr(const char *name, int mode, char **err);		/* in dl_unix.go */
+import "C"
+
+// Promote makes the symbols of m, which was opened with Local, available to libraries loaded afterward, as if it had been opened with Global.
+// This is for a plugin that loads plugins of its own that need its symbols.
+// It reopens the file m was loaded from with RTLD_GLOBAL and RTLD_NOLOAD, which the dynamic linker takes as a request to promote the library already loaded; the extra reference is dropped again right away.
+// It needs Module.Path to work.
+func (m Module) Promote() (err error) {
+	var e *C.char
+
+	if t := m.boundThread(); t != nil {
+		t.Do(func() {
+			err = m.Promote()
+		})
+		return err
+	}
+	if C.havePromote == 0 {
+		return ErrUnsupported
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if err := m.closedError("info", ""); err != nil {
+		return err
+	}
+	mi := modules[m]
+	if mi != nil && mi.name == "" {
+		return nil		// the program itself is always global
+	}
+	path, err := m.path()
+	if err != nil {
+		return err
+	}
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	h := Module(C.dlopenerr(cpath, C.promoteMode, &e))
+	if h == 0 {
+		return fmt.Errorf("dl: could not promote %s: %w", path, takeError(e))
+	}
+	h.sysclose()
+	if h != m {
+		return fmt.Errorf("dl: could not promote %s: the file it was loaded from now holds another library", path)
+	}
+	if mi != nil {
+		mi.mode = mi.mode &^ Local | Global
+	}
+	return nil
+}
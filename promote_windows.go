@@ -0,0 +1,9 @@
+// 14 october 2026
+
+package dl
+
+// Promote makes the symbols of m available to libraries loaded afterward.
+// Windows has no global symbol scope for it to join, so it returns ErrUnsupported.
+func (m Module) Promote() error {
+	return ErrUnsupported
+}
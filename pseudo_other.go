@@ -0,0 +1,15 @@
+// 14 october 2026
+
+//go:build !unix
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// NextSymbol looks up the given named symbol in the objects loaded after the one containing this package.
+// This system has no RTLD_NEXT, so it returns ErrUnsupported.
+func NextSymbol(name string) (unsafe.Pointer, error) {
+	return nil, ErrUnsupported
+}
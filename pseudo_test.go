@@ -0,0 +1,53 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"testing"
+
+	"github.com/andlabs/dl"
+)
+
+// pseudoCalls are the methods that need m's link map entry or file, which pseudo-Modules don't have.
+var pseudoCalls = []struct {
+	name	string
+	call	func(m dl.Module) error
+}{
+	{"Lmid", func(m dl.Module) error { _, err := m.Lmid(); return err }},
+	{"Info", func(m dl.Module) error { _, err := m.Info(); return err }},
+	{"VerifyIntegrity", func(m dl.Module) error { _, err := m.VerifyIntegrity(); return err }},
+	{"NextSymbol", func(m dl.Module) error { _, err := m.NextSymbol("malloc"); return err }},
+	{"BuildID", func(m dl.Module) error { _, err := m.BuildID(); return err }},
+	{"SOName", func(m dl.Module) error { _, err := m.SOName(); return err }},
+	{"Dependencies", func(m dl.Module) error { _, err := m.Dependencies(); return err }},
+	{"LoadedDependencies", func(m dl.Module) error { _, err := m.LoadedDependencies(); return err }},
+	{"ExportedSymbols", func(m dl.Module) error { _, err := m.ExportedSymbols(); return err }},
+	{"Mappings", func(m dl.Module) error { _, err := m.Mappings(); return err }},
+	{"BaseAddress", func(m dl.Module) error { _, err := m.BaseAddress(); return err }},
+	{"Promote", func(m dl.Module) error { return m.Promote() }},
+	{"CheckBindings", func(m dl.Module) error { _, err := m.CheckBindings(); return err }},
+	{"InitFunctions", func(m dl.Module) error { _, err := m.InitFunctions(); return err }},
+	{"TLSModuleID", func(m dl.Module) error { _, err := m.TLSModuleID(); return err }},
+	{"TLSBlock", func(m dl.Module) error { _, err := m.TLSBlock(); return err }},
+	{"TLSSymbol", func(m dl.Module) error { _, err := m.TLSSymbol("errno"); return err }},
+}
+
+func TestPseudoModules(t *testing.T) {
+	for _, p := range []struct {
+		name	string
+		m	dl.Module
+	}{
+		{"Default", dl.Default()},
+		{"Next", dl.Next()},
+	} {
+		for _, c := range pseudoCalls {
+			t.Run(p.name + "/" + c.name, func(t *testing.T) {
+				if err := c.call(p.m); err == nil {
+					t.Errorf("%s on %s() succeeded; want an error", c.name, p.name)
+				}
+			})
+		}
+	}
+}
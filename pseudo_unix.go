@@ -0,0 +1,45 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// static void *dlDefault(void) { return RTLD_DEFAULT; }
+// static void *dlNext(void) { return RTLD_NEXT; }
+import "C"
+
+// Default returns a pseudo-Module whose Symbol searches every object loaded with Global, in load order, starting with the program itself.
+// This finds symbols that are already loaded by any part of the process, without opening anything.
+// This is RTLD_DEFAULT; note that on some systems its value is 0, which is also what a failed Open returns.
+// Closing it does nothing.
+func Default() Module {
+	return Module(uintptr(C.dlDefault()))
+}
+
+// Next returns a pseudo-Module whose Symbol searches the objects loaded after the one making the lookup, skipping the first definition.
+// This is RTLD_NEXT.
+// Since the lookup is made from the program itself (where this package's C code lives), Next finds the definition in the first loaded library rather than the program; it's what an interposer built into the program wants.
+// To do the same relative to a library you opened, use Module.NextSymbol.
+// Closing it does nothing.
+func Next() Module {
+	return Module(uintptr(C.dlNext()))
+}
+
+// pseudo returns whether m is one of the pseudo-Modules, which must never be given to dlclose().
+func (m Module) pseudo() bool {
+	return m == Default() || m == Next()
+}
+
+// NextSymbol looks up the given named symbol with Next: it finds the definition in the first object loaded after the one containing this package, skipping that object's own.
+// This is what an interposer written partly in Go wants, to call the function it overrides: built with -buildmode=c-shared and loaded with LD_PRELOAD (or DYLD_INSERT_LIBRARIES on OS X), this package is part of the interposer, so NextSymbol finds the real definition behind it.
+// In an ordinary program, it finds the definition in the first library after the program.
+// Windows has no equivalent, so there NextSymbol returns ErrUnsupported.
+func NextSymbol(name string) (unsafe.Pointer, error) {
+	return Next().Symbol(name)
+}
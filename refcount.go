@@ -0,0 +1,34 @@
+// 14 october 2026
+
+package dl
+
+// RefCounts says how many references to a library this package knows about, as returned by Module.RefCount.
+type RefCounts struct {
+	// Opens is the number of opens of the library through this package that have not been closed yet.
+	Opens	int
+
+	// System is how many of the dynamic linker's references to the library this package holds.
+	// It is less than Opens while SetShareOpens(true) is in effect, since shared opens don't take one each.
+	System	int
+}
+
+// RefCount returns how many references to m this package holds, for diagnostics; for instance, to find out whether closing m will give up the last reference this package has.
+// Other code in the process (and the dependencies of other libraries) can hold references of their own, which can't be counted: glibc only keeps its count in a private structure that changes between versions, and other systems don't keep it anywhere reachable at all.
+// So a Close that drops System to 0 is necessary, but not sufficient, for the library to be unloaded.
+// A Module not opened through this package has no references this package knows of.
+func (m Module) RefCount() (RefCounts, error) {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if err := m.closedError("info", ""); err != nil {
+		return RefCounts{}, err
+	}
+	mi := modules[m]
+	if mi == nil {
+		return RefCounts{}, nil
+	}
+	return RefCounts{
+		Opens:	mi.refs,
+		System:	mi.sysrefs,
+	}, nil
+}
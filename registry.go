@@ -0,0 +1,91 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpenModule describes a Module currently open through this package, as returned by OpenModules.
+type OpenModule struct {
+	Module	Module
+	Name	string		// the name passed to the first Open; empty for OpenSelf
+	Path		string		// the file that was loaded (see Module.Path), if it can be found out
+	Mode	Mode		// the mode passed to the first Open
+	Opened	time.Time		// when the first Open happened
+	Refs		int			// how many opens have not been closed yet
+
+	// Stack is the stack trace of the first Open, formatted like the stack trace of a panic.
+	// It is only recorded while SetRecordOpenStacks(true) is in effect, and is empty otherwise.
+	Stack	string
+}
+
+var recordStacks = false
+
+// SetRecordOpenStacks controls whether each Open records its stack trace for OpenModules to report.
+// Recording costs a little on every Open of a library not already open, so it is off by default.
+// Libraries opened before recording was turned on have no stack.
+func SetRecordOpenStacks(on bool) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	recordStacks = on
+}
+
+// OpenModules lists every Module currently open through this package, in the order they were first opened.
+// This is meant for debugging and for showing an administrator which libraries a long-running program has loaded.
+func OpenModules() []OpenModule {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	list := make([]OpenModule, 0, len(modules))
+	seqs := make(map[Module]uint64, len(modules))
+	for m, mi := range modules {
+		o := OpenModule{
+			Module:	m,
+			Name:	mi.name,
+			Mode:	mi.mode,
+			Opened:	mi.time,
+			Refs:		mi.refs,
+			Stack:	formatStack(mi.stack),
+		}
+		if p, err := m.path(); err == nil {
+			o.Path = p
+		}
+		list = append(list, o)
+		seqs[m] = mi.seq
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return seqs[list[i].Module] < seqs[list[j].Module]
+	})
+	return list
+}
+
+// callers returns the stack of its caller.
+func callers() []uintptr {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(2, pc)
+	return pc[:n]
+}
+
+// formatStack formats the stack pc, leaving out the frames inside this package.
+func formatStack(pc []uintptr) string {
+	var b strings.Builder
+
+	if len(pc) == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames(pc)
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "github.com/andlabs/dl.") {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			return b.String()
+		}
+	}
+}
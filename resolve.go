@@ -0,0 +1,42 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// Resolver finds the file that a library name passed to Open refers to.
+type Resolver func(name string) (path string, err error)
+
+// resolver is consulted by open; guarded by dllock.
+var resolver Resolver
+
+// SetResolver makes Open (and everything built on it) pass each name through r before loading it, and load the path r returns instead.
+// If r fails, Open fails with its error without calling the dynamic linker.
+// Resolve is the obvious choice for r; pass nil to go back to letting the dynamic linker search on its own.
+// r is called with the package's lock held, so it must not call anything else in this package.
+func SetResolver(r Resolver) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	resolver = r
+}
+
+// ResolveError is returned by Resolve if it cannot find a library.
+// errors.Is(err, ErrNotFound) and errors.Is(err, fs.ErrNotExist) are both true for it.
+type ResolveError struct {
+	Name		string
+	Searched	[]string		// every path tried, in order
+}
+
+func (e *ResolveError) Error() string {
+	if len(e.Searched) == 0 {
+		return "dl: " + e.Name + " not found"
+	}
+	return "dl: " + e.Name + " not found; tried " + strings.Join(e.Searched, ", ")
+}
+
+func (e *ResolveError) Unwrap() []error {
+	return []error{ErrNotFound, fs.ErrNotExist}
+}
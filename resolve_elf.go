@@ -0,0 +1,284 @@
+// 14 october 2026
+
+//go:build !windows && !darwin
+
+package dl
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// Resolve returns the file the dynamic linker would load for name, following the same search as glibc's:
+// 	1. a name containing a slash is used as is
+// 	2. the executable's DT_RPATH, unless it also has a DT_RUNPATH
+// 	3. LD_LIBRARY_PATH
+// 	4. the executable's DT_RUNPATH
+// 	5. /etc/ld.so.cache
+// 	6. the system library directories
+// Files built for another architecture are skipped, as the dynamic linker does.
+// If there is no such file, a *ResolveError listing everything tried is returned.
+//
+// Resolve does not consider dependencies' run paths (which only apply to loading their own dependencies), libraries that are already loaded, or $LIB and $PLATFORM in run paths; it does expand $ORIGIN.
+// Other systems' dynamic linkers mostly follow the same rules without ld.so.cache.
+func Resolve(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		if _, err := os.Stat(name); err != nil {
+			return "", err
+		}
+		return filepath.Abs(name)
+	}
+	e := &ResolveError{
+		Name:	name,
+	}
+	for _, p := range candidates(name) {
+		e.Searched = append(e.Searched, p)
+		if usable(p) {
+			return p, nil
+		}
+	}
+	return "", e
+}
+
+// systemLibraryDirs are searched last; see Resolve.
+var systemLibraryDirs = []string{
+	"/lib",
+	"/usr/lib",
+}
+
+func init() {
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		systemLibraryDirs = append([]string{"/lib64", "/usr/lib64"}, systemLibraryDirs...)
+	}
+}
+
+// candidates returns every path Resolve would consider for name, in order, whether or not it exists.
+func candidates(name string) []string {
+	var dirs []string
+
+	rpath, runpath := exeRunPaths()
+	if len(runpath) == 0 {
+		dirs = append(dirs, rpath...)
+	}
+	dirs = append(dirs, splitPath(os.Getenv("LD_LIBRARY_PATH"))...)
+	dirs = append(dirs, runpath...)
+
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	for _, d := range dirs {
+		add(filepath.Join(d, name))
+	}
+	for _, p := range ldcache(name) {
+		add(p)
+	}
+	for _, d := range systemLibraryDirs {
+		add(filepath.Join(d, name))
+	}
+	return paths
+}
+
+func splitPath(list string) []string {
+	var dirs []string
+
+	for _, d := range strings.Split(list, ":") {
+		if d != "" {
+			dirs = append(dirs, d)
+		}
+	}
+	return dirs
+}
+
+// exeRunPaths returns the DT_RPATH and DT_RUNPATH of the executable, with $ORIGIN expanded.
+func exeRunPaths() (rpath []string, runpath []string) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, nil
+	}
+	f, err := elf.Open(exe)
+	if err != nil {
+		return nil, nil
+	}
+	defer f.Close()
+
+	origin := filepath.Dir(exe)
+	expand := func(tag elf.DynTag) []string {
+		var dirs []string
+		l, _ := f.DynString(tag)
+		for _, s := range l {
+			s = strings.ReplaceAll(s, "${ORIGIN}", origin)
+			s = strings.ReplaceAll(s, "$ORIGIN", origin)
+			dirs = append(dirs, splitPath(s)...)
+		}
+		return dirs
+	}
+	return expand(elf.DT_RPATH), expand(elf.DT_RUNPATH)
+}
+
+// usable returns whether p is an ELF file the dynamic linker could load into this process.
+func usable(p string) bool {
+	f, err := elf.Open(p)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	return archMatches(f)
+}
+
+// goarchMachines maps GOARCH to ELF machine types.
+// Architectures not listed are assumed to match.
+var goarchMachines = map[string]elf.Machine{
+	"386":		elf.EM_386,
+	"amd64":		elf.EM_X86_64,
+	"arm":		elf.EM_ARM,
+	"arm64":		elf.EM_AARCH64,
+	"loong64":	elf.EM_LOONGARCH,
+	"mips":		elf.EM_MIPS,
+	"mipsle":		elf.EM_MIPS,
+	"mips64":		elf.EM_MIPS,
+	"mips64le":	elf.EM_MIPS,
+	"ppc64":		elf.EM_PPC64,
+	"ppc64le":	elf.EM_PPC64,
+	"riscv64":	elf.EM_RISCV,
+	"s390x":		elf.EM_S390,
+}
+
+// archMatches returns whether f was built for the architecture this process is running.
+func archMatches(f *elf.File) bool {
+	class := elf.ELFCLASS32
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		class = elf.ELFCLASS64
+	}
+	if f.Class != class {
+		return false
+	}
+	if m, ok := goarchMachines[runtime.GOARCH]; ok && f.Machine != m {
+		return false
+	}
+	return true
+}
+
+// The format of /etc/ld.so.cache is defined in glibc's sysdeps/generic/dl-cache.h.
+// The old format, which may come first for compatibility, is skipped.
+const (
+	ldcacheOldMagic = "ld.so-1.7.0"
+	ldcacheNewMagic = "glibc-ld.so.cache1.1"
+)
+
+type ldcacheHeader struct {
+	Magic		[20]byte
+	NLibs		uint32
+	LenStrings	uint32
+	Flags		uint8
+	Padding		[3]uint8
+	ExtOffset		uint32
+	Unused		[3]uint32
+}
+
+type ldcacheEntry struct {
+	Flags		int32
+	Key			uint32
+	Value		uint32
+	OSVersion	uint32
+	HWCap		uint64
+}
+
+// ldcache returns the paths /etc/ld.so.cache lists for name, in order.
+// Entries for other architectures are included; Resolve's usable will skip them.
+func ldcache(name string) []string {
+	var paths []string
+
+	for _, e := range ldcacheEntries() {
+		if e[0] == name {
+			paths = append(paths, e[1])
+		}
+	}
+	return paths
+}
+
+// ldcacheEntries returns every name and path /etc/ld.so.cache lists, in order.
+func ldcacheEntries() [][2]string {
+	data, err := os.ReadFile("/etc/ld.so.cache")
+	if err != nil {
+		return nil
+	}
+	if bytes.HasPrefix(data, []byte(ldcacheOldMagic)) {
+		// 12 bytes of magic, a uint32 count, and 12 bytes per entry, aligned to 8
+		if len(data) < 16 {
+			return nil
+		}
+		n := binary.NativeEndian.Uint32(data[12:])
+		off := (16 + uint64(n) * 12 + 7) &^ 7
+		if off >= uint64(len(data)) {
+			return nil
+		}
+		data = data[off:]
+	}
+	if !bytes.HasPrefix(data, []byte(ldcacheNewMagic)) {
+		return nil
+	}
+
+	// ldconfig writes the cache in the machine's own byte order
+	var hdr ldcacheHeader
+	r := bytes.NewReader(data)
+	if binary.Read(r, binary.NativeEndian, &hdr) != nil {
+		return nil
+	}
+
+	str := func(off uint32) string {
+		if uint64(off) >= uint64(len(data)) {
+			return ""
+		}
+		s := data[off:]
+		if i := bytes.IndexByte(s, 0); i != -1 {
+			s = s[:i]
+		}
+		return string(s)
+	}
+	var entries [][2]string
+	for i := uint32(0); i < hdr.NLibs; i++ {
+		var e ldcacheEntry
+		if binary.Read(r, binary.NativeEndian, &e) != nil {
+			break
+		}
+		entries = append(entries, [2]string{str(e.Key), str(e.Value)})
+	}
+	return entries
+}
+
+// diagnoseOpen looks at the file the dynamic linker most likely tried to load for name to work out why loading it failed: ErrNotFound if there is no such file, ErrBadFormat if it isn't a shared object or executable for this architecture, or nil if neither.
+func diagnoseOpen(name string) error {
+	p, err := Resolve(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+		return nil
+	}
+	file, err := os.Open(p)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	f, err := elf.NewFile(file)
+	if err != nil {
+		return ErrBadFormat
+	}
+	if (f.Type != elf.ET_DYN && f.Type != elf.ET_EXEC) || !archMatches(f) {
+		return ErrBadFormat
+	}
+	return nil
+}
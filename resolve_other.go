@@ -0,0 +1,20 @@
+// 14 october 2026
+
+//go:build windows || darwin
+
+package dl
+
+// Resolve returns the file the dynamic linker would load for name.
+// This system's search rules are not the ELF ones Resolve knows, so it returns ErrUnsupported.
+func Resolve(name string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func candidates(name string) []string {
+	return nil
+}
+
+// diagnoseOpen works out why loading name failed by looking at the file; on this system, that is left to classify().
+func diagnoseOpen(name string) error {
+	return nil
+}
@@ -0,0 +1,119 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestResolveLibraryPath(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	first, second := t.TempDir(), t.TempDir()
+	// the first directory's copy is not a library, so it must be skipped
+	if err := os.WriteFile(filepath.Join(first, "libresolvetest.so"), []byte("this is not a library, just some text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	copyFile(t, lib, filepath.Join(second, "libresolvetest.so"))
+	t.Setenv("LD_LIBRARY_PATH", first + ":" + second)
+
+	got, err := dl.Resolve("libresolvetest.so")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(second, "libresolvetest.so"); got != want {
+		t.Errorf("Resolve = %q; want %q", got, want)
+	}
+
+	copyFile(t, lib, filepath.Join(first, "libresolvetest.so"))
+	got, err = dl.Resolve("libresolvetest.so")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := filepath.Join(first, "libresolvetest.so"); got != want {
+		t.Errorf("Resolve with copies in both directories = %q; want the first, %q", got, want)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	t.Setenv("LD_LIBRARY_PATH", first + ":" + second)
+
+	_, err := dl.Resolve("libdltestnothere.so")
+	if !errors.Is(err, dl.ErrNotFound) || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Resolve of a missing library error = %v; want ErrNotFound and fs.ErrNotExist", err)
+	}
+	var re *dl.ResolveError
+	if !errors.As(err, &re) {
+		t.Fatalf("Resolve error is %T; want *ResolveError", err)
+	}
+	want := []string{filepath.Join(first, "libdltestnothere.so"), filepath.Join(second, "libdltestnothere.so")}
+	if len(re.Searched) < 2 || re.Searched[0] != want[0] || re.Searched[1] != want[1] {
+		t.Errorf("Searched = %q; want it to start with LD_LIBRARY_PATH, %q", re.Searched, want)
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	dir, file := filepath.Split(lib)
+	t.Chdir(dir)
+	got, err := dl.Resolve("./" + file)
+	if err != nil || got != lib {
+		t.Errorf("Resolve(%q) = %q, %v; want %q", "./" + file, got, err, lib)
+	}
+	if _, err := dl.Resolve("./nothere.so"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Resolve of a missing path error = %v; want fs.ErrNotExist", err)
+	}
+}
+
+func TestResolveCache(t *testing.T) {
+	t.Setenv("LD_LIBRARY_PATH", "")
+	got, err := dl.Resolve("libc.so.6")
+	if err != nil {
+		t.Skipf("Resolve(libc.so.6): %v", err)		// musl, for one, has no libc.so.6
+	}
+	if !filepath.IsAbs(got) || filepath.Base(got) != "libc.so.6" {
+		t.Errorf("Resolve(libc.so.6) = %q; want an absolute path to it", got)
+	}
+}
+
+func TestSetResolver(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	refused := errors.New("refused by the test")
+	var asked []string
+	dl.SetResolver(func(name string) (string, error) {
+		asked = append(asked, name)
+		if name == "libresolvertest.so" {
+			return lib, nil
+		}
+		return "", refused
+	})
+	defer dl.SetResolver(nil)
+
+	m, err := dl.Open("libresolvertest.so", dl.Now)
+	if err != nil {
+		t.Fatalf("Open of a name only the Resolver knows: %v", err)
+	}
+	defer m.Close()
+	if path, err := m.Path(); err != nil || path != lib {
+		t.Errorf("Path = %q, %v; want %q", path, err, lib)
+	}
+
+	if m, err := dl.Open("libc.so.6", dl.Now); !errors.Is(err, refused) {
+		if err == nil {
+			m.Close()
+		}
+		t.Errorf("Open refused by the Resolver error = %v; want the Resolver's error", err)
+	}
+	if len(asked) != 2 || asked[0] != "libresolvertest.so" || asked[1] != "libc.so.6" {
+		t.Errorf("Resolver was asked for %q; want each name Open was given", asked)
+	}
+}
@@ -0,0 +1,46 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"context"
+)
+
+// OpenScopedWithPreloads opens each of preloads in order, then opens name, and arranges for all of them to be closed when ctx is done.
+// The preloads are opened with mode|Global so that their symbols are available to name and to anything name loads in turn.
+// When ctx is done, name is closed first, then the preloads in the reverse of the order they were given, so that nothing is unloaded while something loaded after it may still refer to it.
+// If any open fails, everything opened so far is closed again (in the same order) and the error is returned.
+// If ctx is already done, nothing is opened and ctx.Err() is returned.
+//
+// The returned Module must not be closed by the caller, and must not be used after ctx is done.
+// The closes are done on a goroutine of their own once ctx is done, as by context.AfterFunc, so they may not have happened yet when ctx.Done() is closed; errors from them are discarded.
+// A ctx that is never done (such as context.Background()) never unloads anything.
+func OpenScopedWithPreloads(ctx context.Context, preloads []string, name string, mode Mode) (Module, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	opened := make([]Module, 0, len(preloads) + 1)
+	closeAll := func() {
+		for i := len(opened) - 1; i >= 0; i-- {
+			opened[i].Close()
+		}
+	}
+	for _, p := range preloads {
+		m, err := Open(p, mode | Global)
+		if err != nil {
+			closeAll()
+			return 0, err
+		}
+		opened = append(opened, m)
+	}
+	m, err := Open(name, mode)
+	if err != nil {
+		closeAll()
+		return 0, err
+	}
+	opened = append(opened, m)
+
+	context.AfterFunc(ctx, closeAll)
+	return m, nil
+}
@@ -0,0 +1,71 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestOpenScopedWithPreloadsCancel(t *testing.T) {
+	first := dltest.Build(t, "int scoped_first(void) { return 1; }\n")
+	second := dltest.Build(t, "int scoped_second(void) { return 2; }\n")
+	lib := dltest.Build(t, "int scoped_main(void) { return 3; }\n")
+
+	var mu sync.Mutex
+	closed := []string{}
+	dl.SetTraceFunc(func(e dl.TraceEvent) {
+		if e.Op == "close" && e.Err == nil {
+			mu.Lock()
+			closed = append(closed, e.Library)
+			mu.Unlock()
+		}
+	})
+	defer dl.SetTraceFunc(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m, err := dl.OpenScopedWithPreloads(ctx, []string{first, second}, lib, dl.Now)
+	if err != nil {
+		t.Fatalf("OpenScopedWithPreloads: %v", err)
+	}
+	if _, err := m.Symbol("scoped_main"); err != nil {
+		t.Errorf("Symbol(scoped_main): %v", err)
+	}
+	for _, p := range []string{first, second, lib} {
+		if ok, err := dl.IsLoaded(p); err != nil || !ok {
+			t.Errorf("IsLoaded(%q) before cancel = %v, %v; want true", p, ok, err)
+		}
+	}
+
+	cancel()
+	want := []string{lib, second, first}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		done := len(closed) >= len(want)
+		mu.Unlock()
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(closed, want) {
+		t.Errorf("closed %q after cancel; want %q", closed, want)
+	}
+	for _, p := range want {
+		if ok, err := dl.IsLoaded(p); err != nil || ok {
+			t.Errorf("IsLoaded(%q) after cancel = %v, %v; want false", p, ok, err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"testing"
+
+	"github.com/andlabs/dl"
+)
+
+func TestOpenSelfShared(t *testing.T) {
+	dl.SetShareOpens(true)
+	defer dl.SetShareOpens(false)
+	dl.SetForbidDuplicateOpen(true)
+	defer dl.SetForbidDuplicateOpen(false)
+	var opens []dl.TraceEvent
+	dl.SetTraceFunc(func(e dl.TraceEvent) {
+		if e.Op == "open" {
+			opens = append(opens, e)
+		}
+	})
+	defer dl.SetTraceFunc(nil)
+
+	m1, err := dl.OpenSelf(dl.Now)
+	if err != nil {
+		t.Fatalf("OpenSelf: %v", err)
+	}
+	defer m1.Close()
+	m2, err := dl.OpenSelf(dl.Now)
+	if err != nil {
+		t.Fatalf("OpenSelf again, with duplicate opens forbidden: %v", err)
+	}
+	defer m2.Close()
+
+	rc, err := m1.RefCount()
+	if err != nil {
+		t.Fatalf("RefCount: %v", err)
+	}
+	if rc.Opens < 2 || rc.System != 1 {
+		t.Errorf("RefCount = %+v after two shared OpenSelfs; want at least 2 opens sharing 1 system reference", rc)
+	}
+	dl.SetTraceFunc(nil)
+	if len(opens) != 2 || opens[0].Module != m1 || opens[1].Module != m2 || opens[0].Library != "" {
+		t.Errorf("traced opens = %+v; want the two OpenSelfs", opens)
+	}
+}
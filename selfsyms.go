@@ -0,0 +1,16 @@
+// 14 october 2026
+
+package dl
+
+// SelfSymbols lists the symbols the program itself exports to the libraries it loads, as ExportedSymbols does for a library.
+// This is for a host that offers an API to its plugins by exporting functions: it can check that what it means to export really is.
+// Executables only export symbols they were linked to export; a Go program needs, for instance, -ldflags=-extldflags=-rdynamic for its C functions to be visible to plugins at all.
+// It works wherever ExportedSymbols does, reading /proc/self/exe on Linux.
+func SelfSymbols() ([]ExportedSymbol, error) {
+	m, err := OpenSelf(Lazy)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+	return m.ExportedSymbols()
+}
@@ -0,0 +1,61 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"sort"
+)
+
+var shareOpens = false
+
+// SetShareOpens controls whether opens of a library that is already open through this package share one reference from the system.
+// While it is on, opening a library again returns the same Module as before (as it always does) without taking another reference from the system; the library is then only really closed (with dlclose() or FreeLibrary()) when the last of the opens is closed.
+// This makes no difference to when the library is unloaded, but it lets independent parts of a program open and close the same library without keeping track of each other, and it lets CloseAll undo everything at once.
+// It is off by default.
+func SetShareOpens(on bool) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	shareOpens = on
+}
+
+// share gives back the system reference a new open of m just took, if opens are shared and m already has one.
+// The caller must hold dllock.
+func share(m Module) {
+	mi := modules[m]
+	if shareOpens && mi.sysrefs > 1 {
+		m.sysclose()
+		mi.sysrefs--
+	}
+}
+
+// CloseAll closes every Module opened through this package and not yet closed, however many times each was opened, most recently opened first.
+// It is meant to be called when shutting down; every Module and symbol from this package is invalid afterward.
+// Modules that fail to close are forgotten anyway; the returned error lists them.
+func CloseAll() error {
+	var errs []error
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	ms := make([]Module, 0, len(modules))
+	for m := range modules {
+		ms = append(ms, m)
+	}
+	sort.Slice(ms, func(i, j int) bool {
+		return modules[ms[i]].seq > modules[ms[j]].seq
+	})
+	for _, m := range ms {
+		mi := modules[m]
+		for ; mi.sysrefs > 0; mi.sysrefs-- {
+			if err := m.unload(); err != nil {
+				errs = append(errs, m.closeError(err))
+				break
+			}
+		}
+		ncloses.Add(int64(mi.refs))
+		mi.closes.Add(1)
+		forget(m, mi)
+	}
+	return errors.Join(errs...)
+}
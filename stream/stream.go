@@ -0,0 +1,103 @@
+// 14 october 2026
+
+/*
+Package stream adapts stream-like C APIs loaded with package dl to Go's io interfaces.
+
+The C functions must both have the signature
+
+	ssize_t f(void *handle, void *buf, size_t n);
+
+and behave like read(2) and write(2): return the number of bytes transferred, 0 at end of file (for reading), or a negative value on error, optionally setting errno.
+*/
+package stream
+
+import (
+	"io"
+	"errors"
+	"unsafe"
+)
+
+// #include <sys/types.h>
+// #include <stddef.h>
+// #include <errno.h>
+// ssize_t callrw(void *p, void *handle, void *buf, size_t n)
+// {
+// 	ssize_t (*f)(void *, void *, size_t);
+//
+// 	*((void **) (&f)) = p;
+// 	errno = 0;
+// 	return (*f)(handle, buf, n);
+// }
+import "C"
+
+type readWriter struct {
+	read		unsafe.Pointer
+	write	unsafe.Pointer
+	handle	unsafe.Pointer
+}
+
+// ErrNoFunction is returned by Read or Write if the corresponding symbol passed to NewReadWriter was nil.
+var ErrNoFunction = errors.New("stream: no C function for this operation")
+
+// errFailed is returned if the C function fails without setting errno.
+var errFailed = errors.New("stream: C function returned an error")
+
+// NewReadWriter returns an io.ReadWriter that calls readSym and writeSym (as returned by dl.Module.Symbol) on handle.
+// Either symbol may be nil if the stream only goes in one direction; the corresponding method will then return ErrNoFunction.
+// handle is passed to the C functions as is and is never dereferenced by Go.
+func NewReadWriter(readSym, writeSym unsafe.Pointer, handle unsafe.Pointer) io.ReadWriter {
+	return &readWriter{
+		read:	readSym,
+		write:	writeSym,
+		handle:	handle,
+	}
+}
+
+func call(f unsafe.Pointer, handle unsafe.Pointer, p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n, err := C.callrw(f, handle, unsafe.Pointer(&p[0]), C.size_t(len(p)))
+	if n < 0 {
+		if err == nil {
+			err = errFailed
+		}
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// Read calls the C read function once.
+// A return of 0 from the C function is reported as io.EOF.
+func (rw *readWriter) Read(p []byte) (int, error) {
+	if rw.read == nil {
+		return 0, ErrNoFunction
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	n, err := call(rw.read, rw.handle, p)
+	if err == nil && n == 0 {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Write calls the C write function as many times as needed to write all of p.
+func (rw *readWriter) Write(p []byte) (int, error) {
+	if rw.write == nil {
+		return 0, ErrNoFunction
+	}
+	written := 0
+	for written < len(p) {
+		n, err := call(rw.write, rw.handle, p[written:])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if n == 0 {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
+}
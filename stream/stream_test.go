@@ -0,0 +1,86 @@
+// 14 october 2026
+
+//go:build unix
+
+package stream_test
+
+import (
+	"errors"
+	"io"
+	"syscall"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+	"github.com/andlabs/dl/stream"
+)
+
+// pipeSource is a library with a small pipe: what is written can be read back, until the pipe is full.
+const pipeSource = `
+#include <errno.h>
+#include <string.h>
+#include <sys/types.h>
+
+static char buf[16];
+static size_t start, end;
+
+ssize_t piperead(void *handle, void *p, size_t n)
+{
+	if (n > end - start)
+		n = end - start;
+	memcpy(p, buf + start, n);
+	start += n;
+	return n;
+}
+
+ssize_t pipewrite(void *handle, void *p, size_t n)
+{
+	if (end == sizeof buf) {
+		errno = ENOSPC;
+		return -1;
+	}
+	if (n > 4)
+		n = 4;		/* make Write loop */
+	if (n > sizeof buf - end)
+		n = sizeof buf - end;
+	memcpy(buf + end, p, n);
+	end += n;
+	return n;
+}
+`
+
+func TestReadWriter(t *testing.T) {
+	lib := dltest.Build(t, pipeSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	defer m.Close()
+	syms, err := m.Symbols("piperead", "pipewrite")
+	if err != nil {
+		t.Fatalf("Symbols: %v", err)
+	}
+	rw := stream.NewReadWriter(syms["piperead"], syms["pipewrite"], nil)
+
+	if n, err := rw.Write([]byte("hello, world")); n != 12 || err != nil {
+		t.Fatalf("Write = %d, %v; want 12, nil", n, err)
+	}
+	got, err := io.ReadAll(rw)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello, world" {
+		t.Errorf("read back %q; want %q", got, "hello, world")
+	}
+
+	// fill the pipe; the C function's errno comes back as the error
+	n, err := rw.Write([]byte("0123456789"))
+	if n != 4 || !errors.Is(err, syscall.ENOSPC) {
+		t.Errorf("Write to a full pipe = %d, %v; want 4, ENOSPC", n, err)
+	}
+
+	ro := stream.NewReadWriter(syms["piperead"], nil, nil)
+	if _, err := ro.Write([]byte("x")); !errors.Is(err, stream.ErrNoFunction) {
+		t.Errorf("Write without a write function error = %v; want ErrNoFunction", err)
+	}
+}
@@ -0,0 +1,37 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// ErrNullSymbol is returned, wrapped in an *Error, by StrictSymbol for a symbol that exists but whose value is nil.
+var ErrNullSymbol = errors.New("dl: symbol is NULL")
+
+// StrictSymbol is like Symbol, but also fails if the symbol's value is nil, so checking err is enough before using the symbol.
+// Use errors.Is with ErrNullSymbol and ErrSymbolNotFound to tell the two failures apart.
+func (m Module) StrictSymbol(name string) (unsafe.Pointer, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, func() (unsafe.Pointer, error) {
+			return m.StrictSymbol(name)
+		})
+	}
+	defer symlock()()
+
+	s, err := m.symbol(name)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, &Error{
+			Op:		"symbol",
+			Library:	m.libraryName(),
+			Symbol:	name,
+			Err:		ErrNullSymbol,
+			Msg:		"dl: symbol " + name + " is NULL",
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,63 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+const symbolSource = "int answer(void) { return 42; }\n"
+
+// openSymbolLibrary opens a library that defines answer(), closing it when tb ends.
+func openSymbolLibrary(tb testing.TB) dl.Module {
+	tb.Helper()
+	lib := dltest.Build(tb, symbolSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		tb.Fatalf("Open(%q): %v", lib, err)
+	}
+	tb.Cleanup(func() {
+		m.Close()
+	})
+	return m
+}
+
+func TestSymbolAllocations(t *testing.T) {
+	m := openSymbolLibrary(t)
+	if _, err := m.Symbol("answer"); err != nil {
+		t.Fatalf("Symbol(answer): %v", err)
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		m.Symbol("answer")
+	}); n != 0 {
+		t.Errorf("cached Symbol made %v allocations; want 0", n)
+	}
+	if n := testing.AllocsPerRun(100, func() {
+		m.LookupOK("nothere")
+	}); n != 0 {
+		t.Errorf("LookupOK of a missing symbol made %v allocations; want 0", n)
+	}
+}
+
+func BenchmarkSymbol(b *testing.B) {
+	m := openSymbolLibrary(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := m.Symbol("answer"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLookupOKMissing(b *testing.B) {
+	m := openSymbolLibrary(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		m.LookupOK("nothere")
+	}
+}
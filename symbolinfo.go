@@ -0,0 +1,34 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+)
+
+// SymbolInfo is what a symbol table entry says about a symbol, as returned by Module.SymbolInfo.
+type SymbolInfo struct {
+	Name		string
+	Object		string		// the file whose symbol table the entry came from; this can be one of m's dependencies
+	Addr			uintptr		// where Symbol found it; for thread-local symbols, the address of the calling thread's copy
+	Binding		elf.SymBind	// elf.STB_GLOBAL or elf.STB_WEAK, or elf.STB_LOOS for STB_GNU_UNIQUE; weak symbols are usually stubs or defaults meant to be overridden
+	Visibility	elf.SymVis	// elf.STV_DEFAULT or elf.STV_PROTECTED
+	Type		elf.SymType	// such as elf.STT_FUNC or elf.STT_OBJECT
+	Size			uint64		// in bytes; for data, the size of the variable, and for functions, the size of the code (0 if the toolchain didn't record it)
+}
+
+// SymbolInfo looks up the given named symbol in m, as Symbol does, and returns what the symbol table entry of the definition found says about it.
+// Use it to tell a weak stub from a real implementation, or to find out how big a variable is before copying it.
+// This only works on Linux, and returns ErrUnsupported elsewhere.
+func (m Module) SymbolInfo(name string) (*SymbolInfo, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, func() (*SymbolInfo, error) {
+			return m.SymbolInfo(name)
+		})
+	}
+	p, err := m.Symbol(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.symbolInfo(name, p)
+}
@@ -0,0 +1,101 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"fmt"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <link.h>
+// #include <stdlib.h>
+// #include <string.h>
+// struct syminfo {
+// 	char *object;
+// 	unsigned char info;
+// 	unsigned char other;
+// 	unsigned long long size;
+// };
+// #ifdef __GLIBC__
+// /* fills in si from the symbol table entry of the symbol at exactly addr, and returns whether there is one; si->object must be freed */
+// static int syminfo(void *addr, struct syminfo *si)
+// {
+// 	Dl_info info;
+// 	ElfW(Sym) *sym = NULL;
+//
+// 	if (dladdr1(addr, &info, (void **) (&sym), RTLD_DL_SYMENT) == 0)
+// 		return 0;
+// 	if (sym == NULL || info.dli_saddr != addr)
+// 		return 0;
+// 	si->object = strdup(info.dli_fname);
+// 	si->info = sym->st_info;
+// 	si->other = sym->st_other;
+// 	si->size = sym->st_size;
+// 	return 1;
+// }
+// #else
+// static int syminfo(void *addr, struct syminfo *si) { return 0; }
+// #endif
+import "C"
+
+// symbolInfo works out what the symbol table says about name, found by Symbol at p.
+// As with symbolKind, glibc's dladdr1() can say directly, for the definition that was actually found; otherwise the dynamic symbol table of m's own file is read, which misses definitions in its dependencies.
+func (m Module) symbolInfo(name string, p unsafe.Pointer) (*SymbolInfo, error) {
+	var si C.struct_syminfo
+
+	if p != nil {
+		dllock.Lock()
+		ok := C.syminfo(p, &si)
+		dllock.Unlock()
+		if ok != 0 {
+			defer C.free(unsafe.Pointer(si.object))
+			return &SymbolInfo{
+				Name:		name,
+				Object:		C.GoString(si.object),
+				Addr:			uintptr(p),
+				Binding:		elf.ST_BIND(uint8(si.info)),
+				Visibility:	elf.ST_VISIBILITY(uint8(si.other)),
+				Type:		elf.ST_TYPE(uint8(si.info)),
+				Size:			uint64(si.size),
+			}, nil
+		}
+	}
+
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	f, err := elf.Open(o.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	syms, err := f.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range syms {
+		if s.Name != name || !isExported(s) {
+			continue
+		}
+		t := elf.ST_TYPE(s.Info)
+		if t != elf.STT_TLS && t != elf.STT_GNU_IFUNC && o.bias + uintptr(s.Value) != uintptr(p) {
+			continue		// another version of the symbol
+		}
+		return &SymbolInfo{
+			Name:		name,
+			Object:		o.path,
+			Addr:			uintptr(p),
+			Binding:		elf.ST_BIND(s.Info),
+			Visibility:	elf.ST_VISIBILITY(s.Other),
+			Type:		t,
+			Size:			s.Size,
+		}, nil
+	}
+	return nil, fmt.Errorf("dl: %s is not defined in %s itself, and the system can't say which of its dependencies defines it", name, o.path)
+}
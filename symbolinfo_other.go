@@ -0,0 +1,13 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+import (
+	"unsafe"
+)
+
+func (m Module) symbolInfo(name string, p unsafe.Pointer) (*SymbolInfo, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,68 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <stdlib.h>
+// #ifdef __GLIBC__
+// #define haveDlvsym 1
+// #else
+// #define haveDlvsym 0
+// #define dlvsym(h, n, v) NULL
+// #endif
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// static void *dlvsymerr(void *handle, const char *name, const char *version, char **err)
+// {
+// 	void *sym;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	sym = dlvsym(handle, name, version);
+// 	if (sym == NULL)
+// 		*err = dlerrordup();		/* stays NULL if the symbol's value is NULL */
+// 	return sym;
+// }
+import "C"
+
+func init() {
+	features[FeatureDlvsym] = C.haveDlvsym != 0
+}
+
+// SymbolVersion looks up the given version of the given named symbol in the Module; for instance, m.SymbolVersion("memcpy", "GLIBC_2.2.5").
+// Otherwise it's the same as Symbol, including the meaning of a nil symbol with a nil error.
+// This wraps dlvsym(), which only glibc has; with other C libraries it returns ErrUnsupported.
+func (m Module) SymbolVersion(name string, version string) (symbol unsafe.Pointer, err error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, func() (unsafe.Pointer, error) {
+			return m.SymbolVersion(name, version)
+		})
+	}
+	if C.haveDlvsym == 0 {
+		return nil, ErrUnsupported
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if err := m.closedError("symbol", name); err != nil {
+		return nil, err
+	}
+	var e *C.char
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cversion := C.CString(version)
+	defer C.free(unsafe.Pointer(cversion))
+	symbol = C.dlvsymerr(unsafe.Pointer(m), cname, cversion, &e)
+	if symbol == nil {
+		if e == nil {		// no error; symbol value is NULL
+			return nil, nil
+		}
+		return nil, m.symbolError(name, takeError(e))
+	}
+	return symbol, nil
+}
@@ -0,0 +1,15 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// SymbolVersion looks up the given version of the given named symbol in the Module.
+// This wraps dlvsym(), which this system doesn't have, so it returns ErrUnsupported.
+func (m Module) SymbolVersion(name string, version string) (symbol unsafe.Pointer, err error) {
+	return nil, ErrUnsupported
+}
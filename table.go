@@ -0,0 +1,83 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"sync"
+	"unsafe"
+)
+
+// SymbolTable is a cache of symbols looked up in a single Module.
+// Each symbol is looked up the first time it is asked for; after that, the same value is returned without going back to the dynamic linker.
+// SymbolTables are safe for concurrent use.
+type SymbolTable struct {
+	m		Module
+	mi		*modinfo		// nil if m wasn't opened through this package
+	gen		uint64		// mi.closes when the table was made
+	lock		sync.Mutex
+	syms	map[string]unsafe.Pointer
+}
+
+var errTableClosed = errors.New("dl: SymbolTable used after its Module was closed")
+
+// LazyTable returns a new, empty SymbolTable for m.
+// The table is invalidated by any call to m.Close(), even if other references keep the library loaded; after that, Get and Warm will always fail.
+// (Only Modules opened through this package can be told apart from the next library to get the same handle, so a table for any other Module is never invalidated.)
+// Nothing keeps track of the table but the caller, so making many of them costs nothing once they are dropped.
+func (m Module) LazyTable() *SymbolTable {
+	t := &SymbolTable{
+		m:		m,
+		syms:	make(map[string]unsafe.Pointer),
+	}
+	dllock.RLock()
+	defer dllock.RUnlock()
+	if mi := modules[m]; mi != nil {
+		t.mi = mi
+		t.gen = mi.closes.Load()
+	}
+	return t
+}
+
+// closed returns whether m has been closed since t was made.
+func (t *SymbolTable) closed() bool {
+	return t.mi != nil && t.mi.closes.Load() != t.gen
+}
+
+// Get returns the named symbol, looking it up with Symbol if this is the first time it was asked for.
+// Symbols that exist but are nil are cached like any other; failed lookups are not cached.
+func (t *SymbolTable) Get(name string) (unsafe.Pointer, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.get(name)
+}
+
+func (t *SymbolTable) get(name string) (unsafe.Pointer, error) {
+	if t.closed() {
+		return nil, errTableClosed
+	}
+	if s, ok := t.syms[name]; ok {
+		return s, nil
+	}
+	s, err := t.m.Symbol(name)
+	if err != nil {
+		return nil, err
+	}
+	t.syms[name] = s
+	return s, nil
+}
+
+// Warm looks up each of names now, so later calls to Get for them will not need to.
+// Every name is tried; the returned error lists all those that failed.
+func (t *SymbolTable) Warm(names ...string) error {
+	var errs []error
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, name := range names {
+		if _, err := t.get(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,88 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestLazyTableReuse(t *testing.T) {
+	lib := dltest.Build(t, symbolSource)
+	m, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", lib, err)
+	}
+	defer m.Close()
+
+	var lookups atomic.Int32
+	dl.SetTraceFunc(func(e dl.TraceEvent) {
+		if e.Op == "symbol" && e.Module == m {
+			lookups.Add(1)
+		}
+	})
+	defer dl.SetTraceFunc(nil)
+
+	tab := m.LazyTable()
+	first, err := tab.Get("answer")
+	if err != nil {
+		t.Fatalf("Get(answer): %v", err)
+	}
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("first Get made %d lookups; want 1", n)
+	}
+	for i := 0; i < 10; i++ {
+		s, err := tab.Get("answer")
+		if err != nil || s != first {
+			t.Fatalf("Get(answer) again = %p, %v; want %p", s, err, first)
+		}
+	}
+	if n := lookups.Load(); n != 1 {
+		t.Errorf("repeated Gets made %d lookups; want only the first", n)
+	}
+	if _, err := tab.Get("nothere"); err == nil {
+		t.Errorf("Get(nothere) succeeded")
+	}
+	if err := tab.Warm("answer", "nothere"); err == nil {
+		t.Errorf("Warm with a missing name succeeded")
+	}
+
+	// closing one of two references invalidates the table, even though the library stays loaded
+	m2, err := dl.Open(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("second Open: %v", err)
+	}
+	if err := m2.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := tab.Get("answer"); err == nil {
+		t.Errorf("Get after Close succeeded; want an error")
+	}
+	if _, err := m.LazyTable().Get("answer"); err != nil {
+		t.Errorf("Get from a new table after Close: %v", err)
+	}
+}
+
+func BenchmarkLazyTableGet(b *testing.B) {
+	m := openSymbolLibrary(b)
+	tab := m.LazyTable()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := tab.Get("answer"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLazyTableMany(b *testing.B) {
+	m := openSymbolLibrary(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		m.LazyTable()		// must not pile up anywhere
+	}
+}
@@ -0,0 +1,201 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+// OSThread is a goroutine locked to an OS thread of its own, for libraries that must only be used from the thread that loaded them.
+// Some libraries (certain GUI toolkits and OpenGL drivers, for instance) have constructors or thread-local state that tie them to one thread, which Go's scheduler otherwise gives no way to guarantee.
+type OSThread struct {
+	work	chan func()
+	done	chan struct{}
+	tid		uintptr		// of the thread, from threadID()
+}
+
+// NewOSThread starts a new OSThread.
+// Call Stop when done with it.
+func NewOSThread() *OSThread {
+	t := &OSThread{
+		work:	make(chan func()),
+		done:	make(chan struct{}),
+	}
+	started := make(chan struct{})
+	go t.run(started)
+	<-started
+	return t
+}
+
+func (t *OSThread) run(started chan<- struct{}) {
+	runtime.LockOSThread()
+	// never unlocked; the thread exits with the goroutine, so nothing else ever runs on a thread a library might have changed
+	defer close(t.done)
+	t.tid = threadID()
+	close(started)
+	for f := range t.work {
+		f()
+	}
+}
+
+// Do runs f on t's thread and waits for it to return.
+// Use it for everything else that must happen on that thread, such as calling the library's functions from your cgo code.
+// If Do is called from t's thread already (by f, say), f is just called.
+func (t *OSThread) Do(f func()) {
+	if t.onThread() {
+		f()
+		return
+	}
+	finished := make(chan struct{})
+	t.work <- func() {
+		defer close(finished)
+		f()
+	}
+	<-finished
+}
+
+// onThread returns whether the caller is running on t's thread.
+// Since t's goroutine is locked to its thread, no other goroutine can be running there, so comparing thread IDs is enough.
+// Where threads can't be told apart (threadID() is always 0), the caller is assumed to be elsewhere.
+func (t *OSThread) onThread() bool {
+	return t.tid != 0 && threadID() == t.tid
+}
+
+// Stop ends t's goroutine and its thread after anything running in Do has finished.
+// t must not be used afterward, and Stop must not be called from t's thread.
+func (t *OSThread) Stop() {
+	close(t.work)
+	<-t.done
+}
+
+// ThreadModule is a Module opened on an OSThread; its Symbol and Close run on that thread too.
+// See also OpenOnThread, which makes sure nothing done to the Module can happen on another thread.
+type ThreadModule struct {
+	Module	Module		// the Module itself; using it directly does not go through Thread
+	Thread	*OSThread
+}
+
+// Open opens the named library on t's thread, so that its constructors run there.
+func (t *OSThread) Open(name string, mode Mode) (*ThreadModule, error) {
+	var m Module
+	var err error
+
+	t.Do(func() {
+		m, err = Open(name, mode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ThreadModule{
+		Module:	m,
+		Thread:	t,
+	}, nil
+}
+
+// Symbol is Module.Symbol, run on the OSThread.
+func (m *ThreadModule) Symbol(name string) (symbol unsafe.Pointer, err error) {
+	m.Thread.Do(func() {
+		symbol, err = m.Module.Symbol(name)
+	})
+	return symbol, err
+}
+
+// Close is Module.Close, run on the OSThread, so that the library's destructors run there.
+// It does not stop the OSThread.
+func (m *ThreadModule) Close() (err error) {
+	m.Thread.Do(func() {
+		err = m.Module.Close()
+	})
+	return err
+}
+
+// Call looks up the named function and calls it with call, all on the OSThread; the function must not have a nil address.
+// This is how to call into the library without writing a Do of your own.
+func (m *ThreadModule) Call(name string, call Trampoline) (err error) {
+	m.Thread.Do(func() {
+		var fn unsafe.Pointer
+
+		fn, err = m.Module.StrictSymbol(name)
+		if err == nil {
+			err = call(fn)
+		}
+	})
+	return err
+}
+
+// boundThreads holds the Modules bound to an OSThread by OpenOnThread; it is guarded by dllock.
+// nbound counts them, so that Modules can find out they aren't bound without taking dllock.
+var boundThreads = make(map[Module]*OSThread)
+var nbound atomic.Int32
+
+// OpenOnThread opens the named library on a new OSThread of its own, for libraries that assume everything is done from the thread that loaded them, and binds the Module to that thread.
+// From then on, every method of the Module that calls into the dynamic linker with it (Symbol and the rest of the lookups, Close, Dup, Promote, Path, Info, and so on) runs on the thread, whoever calls it and however they got the Module, so nothing can forget to go through it; call the library's functions with the ThreadModule's Call or Thread.Do.
+// The exceptions are TLSBlock and TLSSymbol, which are about the calling thread by design.
+// The thread belongs to the Module: once the last open of the library through this package is closed, the thread is stopped.
+// If the library is already bound to a thread by an earlier OpenOnThread, this open shares that thread.
+func OpenOnThread(name string, mode Mode) (*ThreadModule, error) {
+	t := NewOSThread()
+	tm, err := t.Open(name, mode)
+	if err != nil {
+		t.Stop()
+		return nil, err
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+	m := tm.Module
+	if bound := boundThreads[m]; bound != nil {
+		tm.Thread = bound
+		go t.Stop()		// not with dllock held; it's idle, but be safe
+		return tm, nil
+	}
+	boundThreads[m] = t
+	nbound.Add(1)
+	onLastClose(m, func() {
+		delete(boundThreads, m)
+		nbound.Add(-1)
+		go t.Stop()		// the last Close may be running on t itself
+	})
+	return tm, nil
+}
+
+// boundThread returns the OSThread m is bound to by OpenOnThread, or nil if it isn't bound to one or the caller is already on it.
+// The caller must not hold dllock.
+func (m Module) boundThread() *OSThread {
+	if nbound.Load() == 0 {
+		return nil
+	}
+	dllock.RLock()
+	t := boundThreads[m]
+	dllock.RUnlock()
+	if t == nil || t.onThread() {
+		return nil
+	}
+	return t
+}
+
+// runOn runs f on t and returns its results, for entry points to hand their work to the thread their Module is bound to.
+func runOn[T any](t *OSThread, f func() (T, error)) (v T, err error) {
+	t.Do(func() {
+		v, err = f()
+	})
+	return v, err
+}
+
+// symbolOn and lookupOKOn are Symbol and LookupOK run on t.
+// They are separate so that the results of Symbol and LookupOK aren't captured by a closure, which would make them allocate even when m isn't bound.
+func (m Module) symbolOn(t *OSThread, name string) (symbol unsafe.Pointer, err error) {
+	t.Do(func() {
+		symbol, err = m.Symbol(name)
+	})
+	return symbol, err
+}
+
+func (m Module) lookupOKOn(t *OSThread, name string) (symbol unsafe.Pointer, ok bool) {
+	t.Do(func() {
+		symbol, ok = m.LookupOK(name)
+	})
+	return symbol, ok
+}
@@ -0,0 +1,68 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestOpenOnThreadDispatch(t *testing.T) {
+	lib := dltest.Build(t, "int answer(void) { return 42; }\n")
+	tm, err := dl.OpenOnThread(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("OpenOnThread(%q): %v", lib, err)
+	}
+	var bound int
+	tm.Thread.Do(func() {
+		bound = syscall.Gettid()
+	})
+
+	var mu sync.Mutex
+	var tids []int
+	dl.SetTraceFunc(func(e dl.TraceEvent) {
+		if e.Op == "symbol" && e.Module == tm.Module {
+			mu.Lock()
+			tids = append(tids, syscall.Gettid())
+			mu.Unlock()
+		}
+	})
+	defer dl.SetTraceFunc(nil)
+
+	m := tm.Module
+	if _, err := m.StrictSymbol("answer"); err != nil {
+		t.Errorf("StrictSymbol(answer): %v", err)
+	}
+	m.InvalidateCache()
+	if _, err := m.Symbol("answer"); err != nil {
+		t.Errorf("Symbol(answer): %v", err)
+	}
+	if _, err := m.Path(); err != nil {
+		t.Errorf("Path: %v", err)
+	}
+	dup, err := m.Dup()
+	if err != nil {
+		t.Fatalf("Dup: %v", err)
+	}
+	dup.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(tids) == 0 {
+		t.Fatalf("no symbol lookups were traced")
+	}
+	for _, tid := range tids {
+		if tid != bound {
+			t.Errorf("lookup ran on thread %d; want the bound thread %d", tid, bound)
+		}
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+// #include <pthread.h>
+// #include <stdint.h>
+// static uintptr_t threadid(void) { return (uintptr_t) pthread_self(); }
+import "C"
+
+// threadID identifies the OS thread the caller is running on.
+func threadID() uintptr {
+	return uintptr(C.threadid())
+}
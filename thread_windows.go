@@ -0,0 +1,11 @@
+// 14 october 2026
+
+package dl
+
+var getCurrentThreadId = kernel32.NewProc("GetCurrentThreadId")
+
+// threadID identifies the OS thread the caller is running on.
+func threadID() uintptr {
+	id, _, _ := getCurrentThreadId.Call()
+	return id
+}
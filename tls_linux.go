@@ -0,0 +1,160 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"fmt"
+	"unsafe"
+)
+
+// #define _GNU_SOURCE
+// #include <dlfcn.h>
+// #include <stddef.h>
+// #include <stdlib.h>
+// extern char *dlerrordup(void);		/* in dl_unix.go */
+// /* RTLD_DI_TLS_MODID and RTLD_DI_TLS_DATA are glibc's; as with info_linux.go, they are an enum and can't be tested for directly */
+// #ifdef __GLIBC__
+// #define haveTLSInfo 1
+// static int tlsinfo(void *handle, int request, void *out, char **err)
+// {
+// 	int r;
+//
+// 	*err = NULL;
+// 	dlerror();
+// 	r = dlinfo(handle, request, out);
+// 	if (r != 0)
+// 		*err = dlerrordup();
+// 	return r;
+// }
+// static int tlsmodid(void *handle, size_t *id, char **err) { return tlsinfo(handle, RTLD_DI_TLS_MODID, id, err); }
+// static int tlsdata(void *handle, void **data, char **err) { return tlsinfo(handle, RTLD_DI_TLS_DATA, data, err); }
+// #else
+// #define haveTLSInfo 0
+// static int tlsmodid(void *handle, size_t *id, char **err) { *err = NULL; *id = 0; return -1; }
+// static int tlsdata(void *handle, void **data, char **err) { *err = NULL; *data = NULL; return -1; }
+// #endif
+// /* this has to all happen in one call, on one thread, since the block found is that thread's */
+// /* if the thread has no block for the library yet, dlsym() of a thread-local symbol makes one and returns the variable's address in it, in glibc and musl alike */
+// static void *tlsaddr(void *handle, size_t offset, const char *name, char **err)
+// {
+// 	void *data = NULL;
+// 	void *sym;
+//
+// 	*err = NULL;
+// 	if (tlsdata(handle, &data, err) == 0 && data != NULL)
+// 		return (char *) data + offset;
+// 	free(*err);
+// 	*err = NULL;
+// 	dlerror();
+// 	sym = dlsym(handle, name);
+// 	if (sym == NULL)
+// 		*err = dlerrordup();
+// 	return sym;
+// }
+import "C"
+
+func init() {
+	features[FeatureTLSInfo] = C.haveTLSInfo != 0
+}
+
+// TLSModuleID returns the dynamic linker's module ID for m's thread-local storage, which is what C code passes to __tls_get_addr(); it is 0 if m has no thread-local variables.
+// This is dlinfo() with RTLD_DI_TLS_MODID; only glibc has it, so TLSModuleID returns ErrUnsupported with other C libraries.
+func (m Module) TLSModuleID() (uintptr, error) {
+	var id C.size_t
+	var e *C.char
+
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.TLSModuleID)
+	}
+
+	if C.haveTLSInfo == 0 {
+		return 0, ErrUnsupported
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+	if err := m.closedError("info", ""); err != nil {
+		return 0, err
+	}
+	if m.pseudo() {
+		return 0, errPseudoPath		// dlinfo() crashes on them
+	}
+	if C.tlsmodid(unsafe.Pointer(m), &id, &e) != 0 {
+		return 0, takeError(e)
+	}
+	return uintptr(id), nil
+}
+
+// TLSBlock returns the start of the calling thread's block of m's thread-local variables, or nil if m has none or the thread hasn't used any of them yet.
+// The block belongs to whichever thread the goroutine happens to be running on, so call runtime.LockOSThread first and keep the goroutine locked for as long as the pointer is used.
+// This is dlinfo() with RTLD_DI_TLS_DATA; only glibc has it, so TLSBlock returns ErrUnsupported with other C libraries.
+func (m Module) TLSBlock() (unsafe.Pointer, error) {
+	var data unsafe.Pointer
+	var e *C.char
+
+	if C.haveTLSInfo == 0 {
+		return nil, ErrUnsupported
+	}
+	dllock.Lock()
+	defer dllock.Unlock()
+	if err := m.closedError("info", ""); err != nil {
+		return nil, err
+	}
+	if m.pseudo() {
+		return nil, errPseudoPath
+	}
+	if C.tlsdata(unsafe.Pointer(m), &data, &e) != 0 {
+		return nil, takeError(e)
+	}
+	return data, nil
+}
+
+// TLSSymbol returns the address of the calling thread's copy of the named thread-local variable (one declared __thread or thread_local) in m.
+// As with TLSBlock, the address belongs to the thread the goroutine is running on; call runtime.LockOSThread first, and don't use the address once the goroutine is unlocked.
+// The variable is looked up in m's own symbol table, so TLSSymbol returns an error wrapping ErrWrongKind if name is not thread-local, and only finds variables m defines itself.
+// The table is read from the file m was loaded from, so this only works where Info does.
+func (m Module) TLSSymbol(name string) (unsafe.Pointer, error) {
+	var e *C.char
+
+	dllock.Lock()
+	o, err := m.object()
+	dllock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	f, err := elf.Open(o.path)
+	if err != nil {
+		return nil, err
+	}
+	syms, err := f.DynamicSymbols()
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	var sym *elf.Symbol
+	for i := range syms {
+		if syms[i].Name == name && isExported(syms[i]) {
+			sym = &syms[i]
+			break
+		}
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+	if err := m.closedError("symbol", name); err != nil {		// closed while the file was being read
+		return nil, err
+	}
+	if sym == nil {
+		return nil, m.symbolError(name, fmt.Errorf("dl: %s does not define %s", o.path, name))
+	}
+	if elf.ST_TYPE(sym.Info) != elf.STT_TLS {
+		return nil, fmt.Errorf("%w: %s is not thread-local", ErrWrongKind, name)
+	}
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	p := C.tlsaddr(unsafe.Pointer(m), C.size_t(sym.Value), cname, &e)
+	if p == nil {
+		return nil, m.symbolError(name, takeError(e))
+	}
+	return p, nil
+}
@@ -0,0 +1,27 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// TLSModuleID returns the dynamic linker's module ID for m's thread-local storage.
+// Only glibc provides it, so it returns ErrUnsupported on this system.
+func (m Module) TLSModuleID() (uintptr, error) {
+	return 0, ErrUnsupported
+}
+
+// TLSBlock returns the start of the calling thread's block of m's thread-local variables.
+// Only glibc provides it, so it returns ErrUnsupported on this system.
+func (m Module) TLSBlock() (unsafe.Pointer, error) {
+	return nil, ErrUnsupported
+}
+
+// TLSSymbol returns the address of the calling thread's copy of the named thread-local variable in m.
+// It needs to read m's symbol table, which is only possible on Linux, so it returns ErrUnsupported here.
+func (m Module) TLSSymbol(name string) (unsafe.Pointer, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,59 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"time"
+)
+
+// TraceEvent describes one operation, as given to a TraceFunc.
+type TraceEvent struct {
+	Op		string		// "open", "symbol", or "close"
+	Library	string		// the name the library was opened with, if known; empty for OpenSelf
+	Symbol	string		// for "symbol", the symbol looked up
+	Module	Module		// the Module opened, searched, or closed; 0 for a failed open
+	Duration	time.Duration	// how long the operation took
+	Err		error		// the error the operation returned, if any
+}
+
+// TraceFunc is called by this package after every open, symbol lookup, and close.
+type TraceFunc func(e TraceEvent)
+
+// tracer is guarded by dllock.
+var tracer TraceFunc
+
+// SetTraceFunc arranges for f to be called after every open (by any of the Open functions), Symbol lookup, and Close, successful or not.
+// Pass nil to stop tracing.
+// This is meant for finding out which libraries a program loads and what it looks up in them, without the firehose of LD_DEBUG.
+//
+// f is called with the package's lock held, so it must not call anything in this package, and it should be quick.
+// Since symbol lookups can run at the same time, f can be called by more than one goroutine at once.
+func SetTraceFunc(f TraceFunc) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	tracer = f
+}
+
+// trace reports an operation that began at start to the TraceFunc and the Instrumentation, if there are any.
+// The caller must hold dllock, for reading at least.
+func trace(op string, library string, symbol string, m Module, start time.Time, err error) {
+	if op == "open" && err != nil {
+		countFailure(library)
+	}
+	if tracer == nil && instrumentation == nil {
+		return
+	}
+	d := time.Since(start)
+	instrument(op, library, symbol, d, err)
+	if tracer == nil {
+		return
+	}
+	tracer(TraceEvent{
+		Op:		op,
+		Library:	library,
+		Symbol:	symbol,
+		Module:	m,
+		Duration:	d,
+		Err:		err,
+	})
+}
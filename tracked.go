@@ -0,0 +1,42 @@
+// 14 october 2026
+
+package dl
+
+// OpenTracked is like Open, but also returns the names of every object that was loaded into the process as a result, including name itself and any dependencies the dynamic linker had to pull in.
+// Objects that were already loaded are not listed, even if name depends on them.
+// The list comes from comparing the loaded objects before and after the open; other cgo code that loads libraries at the same time will confuse it, but opens through this package will not.
+// If the loaded objects cannot be enumerated on this system, ErrUnsupported is returned and nothing is opened; if they can't be enumerated after the open, the library is closed again and the error returned.
+func OpenTracked(name string, mode Mode) (Module, []string, error) {
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	before, err := loadedObjects()
+	if err != nil {
+		return 0, nil, err
+	}
+	m, err := open(name, mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	after, err := loadedObjects()
+	if err != nil {
+		m.close()		// don't leave the caller a Module they've been told to ignore
+		return 0, nil, err
+	}
+
+	type key struct {
+		name	string
+		base	uintptr
+	}
+	seen := make(map[key]bool, len(before))
+	for _, o := range before {
+		seen[key{o.Name, o.Base}] = true
+	}
+	var added []string
+	for _, o := range after {
+		if !seen[key{o.Name, o.Base}] {
+			added = append(added, o.Name)
+		}
+	}
+	return m, added, nil
+}
@@ -0,0 +1,44 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+func TestOpenTracked(t *testing.T) {
+	// without a soname, the dependency is recorded by the path it was linked with, so the dynamic linker finds it without a search path
+	dep := dltest.Build(t, "int depvalue(void) { return 7; }\n")
+	lib := dltest.Build(t, "extern int depvalue(void);\nint value(void) { return depvalue() + 1; }\n", dep)
+
+	m, added, err := dl.OpenTracked(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("OpenTracked(%q): %v", lib, err)
+	}
+	defer m.Close()
+	for _, want := range []string{lib, dep} {
+		found := slices.ContainsFunc(added, func(name string) bool {
+			return filepath.Clean(name) == want
+		})
+		if !found {
+			t.Errorf("OpenTracked listed %q; want it to include %q", added, want)
+		}
+	}
+
+	// opening it again loads nothing new
+	m2, added, err := dl.OpenTracked(lib, dl.Now)
+	if err != nil {
+		t.Fatalf("second OpenTracked(%q): %v", lib, err)
+	}
+	defer m2.Close()
+	if len(added) != 0 {
+		t.Errorf("second OpenTracked listed %q; want nothing", added)
+	}
+}
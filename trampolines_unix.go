@@ -0,0 +1,28 @@
+// 14 october 2026
+
+//go:build unix
+
+package dl
+
+import (
+	"unsafe"
+)
+
+// Each trampoline is a C function that passes its arguments on to the Callback in its slot.
+// The slots are numbered with octal literals so the token pasting below can make 64 of them: 000 through 077.
+
+// #include <stdint.h>
+// extern uintptr_t dlCallback(int, uintptr_t *);		/* in callback_unix.go */
+// #define T(n) static uintptr_t tramp##n(uintptr_t a0, uintptr_t a1, uintptr_t a2, uintptr_t a3, uintptr_t a4, uintptr_t a5) \
+// 	{ uintptr_t a[6] = { a0, a1, a2, a3, a4, a5 }; return dlCallback(n, a); }
+// #define T8(p) T(p##0) T(p##1) T(p##2) T(p##3) T(p##4) T(p##5) T(p##6) T(p##7)
+// T8(00) T8(01) T8(02) T8(03) T8(04) T8(05) T8(06) T8(07)
+// #define P8(p) (void *) tramp##p##0, (void *) tramp##p##1, (void *) tramp##p##2, (void *) tramp##p##3, \
+// 	(void *) tramp##p##4, (void *) tramp##p##5, (void *) tramp##p##6, (void *) tramp##p##7
+// static void *tramps[] = { P8(00), P8(01), P8(02), P8(03), P8(04), P8(05), P8(06), P8(07) };
+// static void *tramp(int n) { return tramps[n]; }
+import "C"
+
+func trampoline(slot int) unsafe.Pointer {
+	return C.tramp(C.int(slot))
+}
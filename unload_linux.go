@@ -0,0 +1,131 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/elf"
+	"fmt"
+	"path/filepath"
+)
+
+// #include <features.h>
+// /* musl's dlclose() never unloads anything */
+// #ifdef __GLIBC__
+// #define dlcloseUnloads 1
+// #else
+// #define dlcloseUnloads 0
+// #endif
+import "C"
+
+// CloseWillUnload reports whether the next Close of m is expected to actually unmap the library, rather than just give up a reference to it.
+// Hosts that reload plugins in place can use it to find out whether a reload will pick up a new build, or whether they should refuse instead.
+// It is false if:
+// 	- the C library never unloads anything (musl)
+// 	- SetLeakOnClose(true) is in effect
+// 	- m is the program itself or a pseudo-Module, or this package holds other references to it
+// 	- m was opened with Nodelete, or its file is marked DF_1_NODELETE, or it defines STB_GNU_UNIQUE symbols (which glibc never unloads)
+// 	- another loaded object lists it as a dependency
+// Otherwise it is true; but references held by other code in the process can't be seen (see Module.RefCount), so true is a best guess.
+// To find out afterward, give the library's path to IsLoaded.
+func (m Module) CloseWillUnload() (bool, error) {
+	if t := m.boundThread(); t != nil {
+		return runOn(t, m.CloseWillUnload)
+	}
+	if C.dlcloseUnloads == 0 {
+		return false, nil
+	}
+
+	dllock.Lock()
+	defer dllock.Unlock()
+
+	if m.pseudo() {
+		return false, nil
+	}
+	if err := m.closedError("info", ""); err != nil {
+		return false, err
+	}
+	if leakOnClose {
+		return false, nil
+	}
+	mi := modules[m]
+	if mi != nil {
+		if mi.name == "" || mi.mode & Nodelete != 0 {
+			return false, nil
+		}
+		if mi.refs > 1 || mi.sysrefs < mi.refs {
+			return false, nil		// the next Close only gives back one of several references, or none at all
+		}
+	}
+	o, err := m.object()
+	if err != nil {
+		return false, err
+	}
+	if o.path == "/proc/self/exe" {
+		return false, nil
+	}
+
+	f, err := elf.Open(o.path)
+	if err != nil {
+		return false, fmt.Errorf("dl: could not read %s: %w", o.path, err)
+	}
+	defer f.Close()
+	if nodelete(f) {
+		return false, nil
+	}
+	names := map[string]bool{
+		filepath.Base(o.path):	true,
+	}
+	if sonames, err := f.DynString(elf.DT_SONAME); err == nil {
+		for _, s := range sonames {
+			names[s] = true
+		}
+	}
+
+	objs, err := loadedObjects()
+	if err != nil {
+		return false, err
+	}
+	for _, obj := range objs {
+		p := obj.Name
+		if obj.Base == o.bias {
+			continue		// m itself
+		}
+		if p == "" {
+			p = "/proc/self/exe"
+		}
+		deps, err := needed(p)
+		if err != nil {
+			continue		// the vDSO, for one, has no file
+		}
+		for _, d := range deps {
+			if names[d] {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// stbGNUUnique is the GNU extension to ELF symbol bindings that debug/elf doesn't name.
+const stbGNUUnique elf.SymBind = 10
+
+// nodelete returns whether glibc will never unload f: it is marked DF_1_NODELETE, or it defines STB_GNU_UNIQUE symbols, which glibc can't take back once other objects may have bound to them.
+func nodelete(f *elf.File) bool {
+	if flags, err := f.DynValue(elf.DT_FLAGS_1); err == nil {
+		for _, v := range flags {
+			if elf.DynFlag1(v) & elf.DF_1_NODELETE != 0 {
+				return true
+			}
+		}
+	}
+	syms, err := f.DynamicSymbols()
+	if err != nil {
+		return false
+	}
+	for _, s := range syms {
+		if elf.ST_BIND(s.Info) == stbGNUUnique && s.Section != elf.SHN_UNDEF {
+			return true
+		}
+	}
+	return false
+}
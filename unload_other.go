@@ -0,0 +1,11 @@
+// 14 october 2026
+
+//go:build !linux
+
+package dl
+
+// CloseWillUnload reports whether the next Close of m is expected to actually unmap the library, rather than just give up a reference to it.
+// It needs to read the library's ELF flags and the dependencies of everything loaded, so on this system it returns ErrUnsupported.
+func (m Module) CloseWillUnload() (bool, error) {
+	return false, ErrUnsupported
+}
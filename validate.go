@@ -0,0 +1,67 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"runtime"
+	"strings"
+)
+
+// FormatError is returned by ValidateFile, and by Open while SetValidate(true) is in effect, for a file that this process can't load.
+// errors.Is(err, ErrBadFormat) is true for it.
+type FormatError struct {
+	Path		string
+	Reason	string		// such as "not a shared object" or "wrong architecture: arm64 object in amd64 process"
+}
+
+func (e *FormatError) Error() string {
+	return "dl: " + e.Path + ": " + e.Reason
+}
+
+func (e *FormatError) Unwrap() error {
+	return ErrBadFormat
+}
+
+// validate is guarded by dllock.
+var validate = false
+
+// SetValidate controls whether Open checks each library with ValidateFile before loading it, to give a clear error instead of whatever the dynamic linker says.
+// Bare names are resolved with Resolve first; where that isn't available (Windows and OS X), only names containing a directory are checked.
+// Files that can't be found are left for the dynamic linker to report.
+// It is off by default.
+func SetValidate(on bool) {
+	dllock.Lock()
+	defer dllock.Unlock()
+	validate = on
+}
+
+// ValidateFile checks that the file at path is a shared library (or position-independent executable) that this process could load, without loading it or running any of its code.
+// It checks the file's format (ELF, Mach-O, or PE, depending on the system), its type, its word size and byte order, and that it was built for this architecture, and returns a *FormatError saying which was wrong.
+// It does not check the library's dependencies.
+func ValidateFile(path string) error {
+	return validateFile(path)
+}
+
+// validateOpen is the check SetValidate turns on; path is what will be passed to the dynamic linker.
+func validateOpen(path string) error {
+	if !strings.ContainsAny(path, `/\`) {
+		p, err := Resolve(path)
+		if err != nil {
+			return nil
+		}
+		path = p
+	}
+	err := validateFile(path)
+	if _, ok := err.(*FormatError); !ok {
+		return nil		// such as not existing
+	}
+	return err
+}
+
+// wrongArch makes the FormatError for a file built for arch.
+func wrongArch(path string, arch string) error {
+	return &FormatError{
+		Path:	path,
+		Reason:	"wrong architecture: " + arch + " object in " + runtime.GOARCH + " process",
+	}
+}
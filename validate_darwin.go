@@ -0,0 +1,60 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/macho"
+	"runtime"
+)
+
+// machoCpus maps GOARCH to Mach-O CPU types.
+var machoCpus = map[string]macho.Cpu{
+	"amd64":	macho.CpuAmd64,
+	"arm64":	macho.CpuArm64,
+}
+
+func validateFile(path string) error {
+	want, ok := machoCpus[runtime.GOARCH]
+	if !ok {
+		return nil
+	}
+
+	var f *macho.File
+	if fat, err := macho.OpenFat(path); err == nil {
+		defer fat.Close()
+		for _, a := range fat.Arches {
+			if a.Cpu == want {
+				f = a.File
+				break
+			}
+		}
+		if f == nil {
+			return &FormatError{
+				Path:	path,
+				Reason:	"wrong architecture: universal binary has no " + runtime.GOARCH + " slice",
+			}
+		}
+	} else {
+		f, err = macho.Open(path)
+		if err != nil {
+			if _, ok := err.(*macho.FormatError); ok {
+				return &FormatError{
+					Path:	path,
+					Reason:	"not a Mach-O file",
+				}
+			}
+			return err
+		}
+		defer f.Close()
+		if f.Cpu != want {
+			return wrongArch(path, f.Cpu.String())
+		}
+	}
+	if f.Type != macho.TypeDylib && f.Type != macho.TypeBundle {
+		return &FormatError{
+			Path:	path,
+			Reason:	"not a dynamic library or bundle (it's " + f.Type.String() + ")",
+		}
+	}
+	return nil
+}
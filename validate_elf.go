@@ -0,0 +1,60 @@
+// 14 october 2026
+
+//go:build !windows && !darwin
+
+package dl
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"unsafe"
+)
+
+func validateFile(path string) error {
+	f, err := elf.Open(path)
+	if err != nil {
+		if _, ok := err.(*elf.FormatError); ok {
+			return &FormatError{
+				Path:	path,
+				Reason:	"not an ELF file",
+			}
+		}
+		return err
+	}
+	defer f.Close()
+
+	if f.Type != elf.ET_DYN {
+		return &FormatError{
+			Path:	path,
+			Reason:	"not a shared object (it's " + f.Type.String() + ")",
+		}
+	}
+	class, bits := elf.ELFCLASS32, "32"
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		class, bits = elf.ELFCLASS64, "64"
+	}
+	if f.Class != class {
+		return &FormatError{
+			Path:	path,
+			Reason:	"wrong word size: " + f.Class.String() + " object in " + bits + "-bit process",
+		}
+	}
+	data := elf.ELFDATA2MSB
+	if binary.NativeEndian.Uint16([]byte{ 1, 0 }) == 1 {
+		data = elf.ELFDATA2LSB
+	}
+	if f.Data != data {
+		return &FormatError{
+			Path:	path,
+			Reason:	"wrong byte order: " + f.Data.String() + " object",
+		}
+	}
+	if !archMatches(f) {
+		arch, ok := elfArchs[f.Machine]
+		if !ok {
+			arch = f.Machine.String()
+		}
+		return wrongArch(path, arch)
+	}
+	return nil
+}
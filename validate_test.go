@@ -0,0 +1,36 @@
+// 14 october 2026
+
+//go:build unix || windows
+
+package dl_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andlabs/dl"
+)
+
+func TestValidateFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	missing := filepath.Join(dir, "nothere")
+	err := dl.ValidateFile(missing)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ValidateFile of a missing file error = %v; want fs.ErrNotExist", err)
+	}
+	if errors.Is(err, dl.ErrBadFormat) {
+		t.Errorf("ValidateFile of a missing file error = %v; want it not to be ErrBadFormat", err)
+	}
+
+	garbage := filepath.Join(dir, "garbage")
+	if err := os.WriteFile(garbage, []byte("this is not a library, just some text"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := dl.ValidateFile(garbage); !errors.Is(err, dl.ErrBadFormat) {
+		t.Errorf("ValidateFile of a text file error = %v; want ErrBadFormat", err)
+	}
+}
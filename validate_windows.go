@@ -0,0 +1,49 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"debug/pe"
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+)
+
+// peMachines maps GOARCH to PE machine types.
+var peMachines = map[string]uint16{
+	"386":	pe.IMAGE_FILE_MACHINE_I386,
+	"amd64":	pe.IMAGE_FILE_MACHINE_AMD64,
+	"arm":	pe.IMAGE_FILE_MACHINE_ARMNT,
+	"arm64":	pe.IMAGE_FILE_MACHINE_ARM64,
+}
+
+func validateFile(path string) error {
+	f, err := pe.Open(path)
+	if err != nil {
+		var perr *fs.PathError
+		if errors.As(err, &perr) {
+			return err		// couldn't open it at all, such as not existing
+		}
+		return &FormatError{		// debug/pe doesn't say which of its other errors are about the format, but reading a file that opened only fails if it's truncated
+			Path:	path,
+			Reason:	"not a PE file: " + err.Error(),
+		}
+	}
+	defer f.Close()
+
+	if f.Characteristics & pe.IMAGE_FILE_DLL == 0 {
+		return &FormatError{
+			Path:	path,
+			Reason:	"not a DLL",
+		}
+	}
+	if want, ok := peMachines[runtime.GOARCH]; ok && f.Machine != want {
+		arch, ok := peArchs[f.Machine]
+		if !ok {
+			arch = fmt.Sprintf("machine type %#x", f.Machine)
+		}
+		return wrongArch(path, arch)
+	}
+	return nil
+}
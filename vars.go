@@ -0,0 +1,87 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// These methods read and write exported C global variables.
+// C ints are assumed to be 32 bits wide, which they are on every system this package runs on.
+// None of them synchronize with the library's own use of the variables.
+
+// VarPointer returns the address of the named global variable.
+// Unlike Symbol, a nil address is an error.
+func (m Module) VarPointer(name string) (unsafe.Pointer, error) {
+	p, err := m.Symbol(name)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("dl: variable %s has a nil address", name)
+	}
+	return p, nil
+}
+
+// Int returns the value of the named global variable of C type int.
+func (m Module) Int(name string) (int, error) {
+	p, err := m.VarPointer(name)
+	if err != nil {
+		return 0, err
+	}
+	return int(*(*int32)(p)), nil
+}
+
+// SetInt sets the named global variable of C type int.
+// v is truncated to 32 bits.
+func (m Module) SetInt(name string, v int) error {
+	p, err := m.VarPointer(name)
+	if err != nil {
+		return err
+	}
+	*(*int32)(p) = int32(v)
+	return nil
+}
+
+// Float64 returns the value of the named global variable of C type double.
+func (m Module) Float64(name string) (float64, error) {
+	p, err := m.VarPointer(name)
+	if err != nil {
+		return 0, err
+	}
+	return *(*float64)(p), nil
+}
+
+// SetFloat64 sets the named global variable of C type double.
+func (m Module) SetFloat64(name string, v float64) error {
+	p, err := m.VarPointer(name)
+	if err != nil {
+		return err
+	}
+	*(*float64)(p) = v
+	return nil
+}
+
+// CString returns a copy of the string pointed to by the named global variable of C type const char * (or char *).
+// A NULL pointer gives an empty string.
+// This is not for char arrays (const char name[] = "..."); the address of those is the string itself, so pass the result of VarPointer to CStringAt instead.
+func (m Module) CString(name string) (string, error) {
+	p, err := m.VarPointer(name)
+	if err != nil {
+		return "", err
+	}
+	return CStringAt(*(*unsafe.Pointer)(p)), nil
+}
+
+// CStringAt returns a copy of the NUL-terminated string at p, or an empty string if p is nil.
+func CStringAt(p unsafe.Pointer) string {
+	if p == nil {
+		return ""
+	}
+	n := 0
+	for *(*byte)(unsafe.Add(p, n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice((*byte)(p), n))
+}
@@ -0,0 +1,71 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Version is the version of a library, as it appears in its file name: libfoo.so.58.1 is Version{58, 1}.
+type Version []int
+
+func (v Version) String() string {
+	s := make([]string, len(v))
+	for i, n := range v {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ".")
+}
+
+// Compare returns -1, 0, or 1 as v is older than, the same as, or newer than w.
+// Missing components count as 0, so 58 and 58.0 are the same.
+func (v Version) Compare(w Version) int {
+	for i := 0; i < len(v) || i < len(w); i++ {
+		a, b := 0, 0
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(w) {
+			b = w[i]
+		}
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseVersion parses the version part of a file name, such as "58.1" from libfoo.so.58.1.
+func parseVersion(s string) (Version, bool) {
+	var v Version
+
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		v = append(v, n)
+	}
+	return v, true
+}
+
+// VersionConstraint says whether a version of a library is acceptable to OpenVersioned.
+type VersionConstraint func(v Version) bool
+
+// VersionAtLeast accepts versions no older than the one given, such as VersionAtLeast(58) or VersionAtLeast(2, 4).
+func VersionAtLeast(v ...int) VersionConstraint {
+	return func(w Version) bool {
+		return w.Compare(v) >= 0
+	}
+}
+
+// VersionBelow accepts versions older than the one given; combine it with VersionAtLeast for a range, such as VersionAtLeast(58), VersionBelow(61).
+func VersionBelow(v ...int) VersionConstraint {
+	return func(w Version) bool {
+		return w.Compare(v) < 0
+	}
+}
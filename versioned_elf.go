@@ -0,0 +1,98 @@
+// 14 october 2026
+
+//go:build !windows && !darwin
+
+package dl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OpenVersioned opens the newest version of the library base (such as "avcodec" or "libavcodec") that meets every one of the constraints, and returns which version that was:
+// 	m, v, err := dl.OpenVersioned("avcodec", dl.Lazy, dl.VersionAtLeast(58))
+// The versions on offer are found by looking for files named libbase.so.N (and libbase.so.N.M and so on) in the same places Resolve looks, and in /etc/ld.so.cache; files for another architecture are skipped.
+// Where the same version is in more than one place, the one Resolve would find first is used.
+// If the newest acceptable version fails to open, the next newest is tried, and so on; if none will, the error says why each failed.
+func OpenVersioned(base string, mode Mode, constraints ...VersionConstraint) (Module, Version, error) {
+	type found struct {
+		path		string
+		version	Version
+	}
+	var all []found
+
+	if !strings.HasPrefix(base, "lib") {
+		base = "lib" + base
+	}
+	prefix := base + ".so."
+	seen := make(map[string]bool)
+	add := func(p string) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		v, ok := parseVersion(strings.TrimPrefix(filepath.Base(p), prefix))
+		if !ok || !usable(p) {
+			return
+		}
+		for _, c := range constraints {
+			if !c(v) {
+				return
+			}
+		}
+		all = append(all, found{p, v})
+	}
+	// scan every directory Resolve searches, plus the ones ld.so.cache lists this library in, for every version there
+	var dirs []string
+	for _, c := range candidates(prefix) {
+		dirs = append(dirs, filepath.Dir(c))
+	}
+	for _, e := range ldcacheEntries() {
+		if strings.HasPrefix(e[0], prefix) {
+			dirs = append(dirs, filepath.Dir(e[1]))
+		}
+	}
+	scanned := make(map[string]bool)
+	for _, dir := range dirs {
+		if scanned[dir] {
+			continue
+		}
+		scanned[dir] = true
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), prefix) {
+				add(filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	if len(all) == 0 {
+		return 0, nil, &Error{
+			Op:		"open",
+			Library:	base,
+			Err:		ErrNotFound,
+			Msg:		"dl: no acceptable version of " + base + " found",
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].version.Compare(all[j].version) > 0
+	})
+	var errs []error
+	for _, f := range all {
+		m, err := Open(f.path, mode)
+		if err == nil {
+			return m, f.version, nil
+		}
+		if errors.Is(err, ErrInvalidMode) || errors.Is(err, ErrUnsupported) {
+			return 0, nil, err
+		}
+		errs = append(errs, err)
+	}
+	return 0, nil, errors.Join(errs...)
+}
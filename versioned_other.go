@@ -0,0 +1,11 @@
+// 14 october 2026
+
+//go:build windows || darwin
+
+package dl
+
+// OpenVersioned opens the newest version of the library base that meets every one of the constraints.
+// It needs the ELF search rules Resolve knows, so on this system it returns ErrUnsupported.
+func OpenVersioned(base string, mode Mode, constraints ...VersionConstraint) (Module, Version, error) {
+	return 0, nil, ErrUnsupported
+}
@@ -0,0 +1,434 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReloadFunc is called by a Watcher when a new version of its library has been opened.
+// It should switch everything that uses symbols from old over to new and return nil, after which the Watcher closes old; old is 0 the first time.
+// If it returns an error instead, the Watcher closes new and keeps old.
+// Except for the first time, it is called on a goroutine of the Watcher's own.
+type ReloadFunc func(old Module, new Module) error
+
+// Watcher keeps a library loaded and reloads it whenever its file changes, for plugins that are rebuilt while the program runs.
+// Each version is loaded from a copy of the file with OpenBytes, so the file can be replaced while a version is loaded and each version really is a separate library; the catch is that $ORIGIN means nothing to them.
+//
+// The file is checked for changes by polling, and reloaded once it has stayed the same for one whole interval, so that a file still being written is not loaded half-finished.
+// To watch a whole directory of plugins, use WatchDir instead.
+type Watcher struct {
+	path	string
+	mode	Mode
+	reload	ReloadFunc
+
+	lock		sync.Mutex
+	cur		Module
+	err		error
+	stamp	fileStamp
+
+	stop		chan struct{}
+	done		chan struct{}
+	closing	sync.Once
+}
+
+type fileStamp struct {
+	mod		time.Time
+	size		int64
+}
+
+func stat(path string) (fileStamp, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{
+		mod:		fi.ModTime(),
+		size:		fi.Size(),
+	}, nil
+}
+
+// Watch opens the library at path with mode, passes it to reload, and then checks path for changes every interval until the Watcher is closed.
+// If the first open or reload fails, Watch returns the error and there is no Watcher.
+func Watch(path string, mode Mode, interval time.Duration, reload ReloadFunc) (*Watcher, error) {
+	w := &Watcher{
+		path:	path,
+		mode:	mode,
+		reload:	reload,
+		stop:		make(chan struct{}),
+		done:	make(chan struct{}),
+	}
+	stamp, err := stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.load(stamp); err != nil {
+		return nil, err
+	}
+	go w.watch(interval)
+	return w, nil
+}
+
+// load opens the current contents of the file, which have the given stamp, and hands them to reload.
+// Only one load runs at a time, since only Watch and then the watching goroutine call it.
+func (w *Watcher) load(stamp fileStamp) error {
+	w.lock.Lock()
+	w.stamp = stamp		// even if this fails, don't try again until the file changes
+	old := w.cur
+	w.lock.Unlock()
+
+	m, err := openCopy(w.path, w.mode)
+	if err != nil {
+		return err
+	}
+	if err := w.reload(old, m); err != nil {
+		m.Close()
+		return err
+	}
+
+	w.lock.Lock()
+	w.cur = m
+	w.lock.Unlock()
+	if old != 0 {
+		return old.Close()
+	}
+	return nil
+}
+
+// openCopy opens a copy of the current contents of the file at path, so the file can change without affecting it.
+func openCopy(path string, mode Mode) (Module, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return OpenBytes(data, mode)
+}
+
+func (w *Watcher) watch(interval time.Duration) {
+	var pending *fileStamp
+
+	defer close(w.done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+		}
+		stamp, err := stat(w.path)
+		if err != nil {		// probably in the middle of being replaced
+			pending = nil
+			continue
+		}
+		w.lock.Lock()
+		changed := stamp != w.stamp
+		w.lock.Unlock()
+		if !changed {
+			pending = nil
+			continue
+		}
+		if pending == nil || *pending != stamp {
+			pending = &stamp		// wait for it to stop changing
+			continue
+		}
+		pending = nil
+		err = w.load(stamp)
+		w.lock.Lock()
+		w.err = err
+		w.lock.Unlock()
+	}
+}
+
+// Module returns the version of the library currently in use.
+func (w *Watcher) Module() Module {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.cur
+}
+
+// Err returns the error from the most recent attempt to reload the library, or nil if it succeeded.
+// A new version that fails to open or that the ReloadFunc rejects is not tried again until the file changes again.
+func (w *Watcher) Err() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.err
+}
+
+// Close stops watching and closes the version of the library currently in use.
+// Closing a Watcher again returns an error wrapping ErrClosed.
+func (w *Watcher) Close() error {
+	err := watcherClosedError(w.path)
+	w.closing.Do(func() {
+		close(w.stop)
+		<-w.done
+
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		m := w.cur
+		w.cur = 0
+		err = m.Close()
+	})
+	return err
+}
+
+// watcherClosedError is the error for closing the Watcher or DirWatcher of path a second time.
+func watcherClosedError(path string) error {
+	return &Error{
+		Op:		"close",
+		Library:	path,
+		Err:		ErrClosed,
+		Msg:		"dl: the watcher of " + path + " is already closed",
+	}
+}
+
+// PluginReloadFunc is called by a DirWatcher when a library in its directory appears, changes, or goes away; name is the library's file name, without the directory.
+// For a library that has just appeared, old is 0; for one that has gone away, new is 0.
+// Otherwise it is the same as a ReloadFunc: return nil and the DirWatcher closes old, or return an error and it closes new and keeps old.
+// (So returning an error for a library that has gone away keeps it loaded.)
+// Except for the libraries that are there when WatchDir is called, it is called on a goroutine of the DirWatcher's own.
+type PluginReloadFunc func(name string, old Module, new Module) error
+
+// DirWatcher is a Watcher for a whole directory of plugins: it keeps every library in the directory loaded, loads new ones as they appear, reloads each one whenever its file changes, and closes the ones that go away.
+// Libraries are loaded the same way as by a Watcher, and are only loaded or reloaded once their files have stayed the same for one whole interval.
+type DirWatcher struct {
+	dir		string
+	pattern	string
+	mode	Mode
+	reload	PluginReloadFunc
+
+	lock		sync.Mutex
+	plugins	map[string]*dirPlugin	// by file name
+	errs		map[string]error		// by file name, or "" for the directory itself
+
+	stop		chan struct{}
+	done		chan struct{}
+	closing	sync.Once
+}
+
+// dirPlugin is one library a DirWatcher is looking after.
+type dirPlugin struct {
+	cur		Module		// 0 if no version would load
+	stamp	fileStamp
+	pending	*fileStamp	// only used by the watching goroutine
+}
+
+// WatchDir opens every library in dir whose file name matches pattern (as in filepath.Match; for instance, "*.so"), passes each one to reload, and then checks dir for new, changed, and removed libraries every interval until the DirWatcher is closed.
+// Subdirectories are not looked in.
+// If dir can't be read, or pattern is malformed, WatchDir returns the error and there is no DirWatcher; a library that fails to load, there or later, doesn't stop the others, and is reported by Err instead.
+func WatchDir(dir string, pattern string, mode Mode, interval time.Duration, reload PluginReloadFunc) (*DirWatcher, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	d := &DirWatcher{
+		dir:		dir,
+		pattern:	pattern,
+		mode:	mode,
+		reload:	reload,
+		plugins:	make(map[string]*dirPlugin),
+		errs:		make(map[string]error),
+		stop:		make(chan struct{}),
+		done:	make(chan struct{}),
+	}
+	if _, err := os.ReadDir(dir); err != nil {
+		return nil, err
+	}
+	d.scan(true)
+	go d.watch(interval)
+	return d, nil
+}
+
+func (d *DirWatcher) watch(interval time.Duration) {
+	defer close(d.done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-t.C:
+		}
+		d.scan(false)
+	}
+}
+
+// scan looks through the directory once, loading what is new or has changed and dropping what has gone.
+// The first scan loads everything at once; after that, files have to stay the same for two scans in a row.
+// Only one scan runs at a time, since only WatchDir and then the watching goroutine call it.
+func (d *DirWatcher) scan(first bool) {
+	entries, err := os.ReadDir(d.dir)
+	d.setErr("", err)
+	if err != nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() {
+			continue
+		}
+		if ok, _ := filepath.Match(d.pattern, name); !ok {
+			continue
+		}
+		seen[name] = true
+		stamp, err := stat(filepath.Join(d.dir, name))
+		if err != nil {		// probably in the middle of being replaced
+			continue
+		}
+		d.lock.Lock()
+		p := d.plugins[name]
+		if p == nil {
+			p = &dirPlugin{}
+			d.plugins[name] = p
+		}
+		changed := stamp != p.stamp
+		d.lock.Unlock()
+		if !changed {
+			p.pending = nil
+			continue
+		}
+		if !first && (p.pending == nil || *p.pending != stamp) {
+			p.pending = &stamp		// wait for it to stop changing
+			continue
+		}
+		p.pending = nil
+		d.setErr(name, d.load(name, p, stamp))
+	}
+
+	d.lock.Lock()
+	var gone []string
+	for name := range d.plugins {
+		if !seen[name] {
+			gone = append(gone, name)
+		}
+	}
+	d.lock.Unlock()
+	sort.Strings(gone)
+	for _, name := range gone {
+		d.setErr(name, d.remove(name))
+	}
+}
+
+// load opens the current contents of the named file, which have the given stamp, and hands them to reload.
+func (d *DirWatcher) load(name string, p *dirPlugin, stamp fileStamp) error {
+	d.lock.Lock()
+	p.stamp = stamp		// even if this fails, don't try again until the file changes
+	old := p.cur
+	d.lock.Unlock()
+
+	m, err := openCopy(filepath.Join(d.dir, name), d.mode)
+	if err != nil {
+		return err
+	}
+	if err := d.reload(name, old, m); err != nil {
+		m.Close()
+		return err
+	}
+
+	d.lock.Lock()
+	p.cur = m
+	d.lock.Unlock()
+	if old != 0 {
+		return old.Close()
+	}
+	return nil
+}
+
+// remove tells reload the named library has gone away, and closes it if reload agrees.
+func (d *DirWatcher) remove(name string) error {
+	d.lock.Lock()
+	old := d.plugins[name].cur
+	d.lock.Unlock()
+
+	if old != 0 {
+		if err := d.reload(name, old, 0); err != nil {
+			return err
+		}
+	}
+	d.lock.Lock()
+	delete(d.plugins, name)
+	d.lock.Unlock()
+	d.setErr(name, nil)
+	if old != 0 {
+		return old.Close()
+	}
+	return nil
+}
+
+// setErr records err as the most recent result for the named file, or for the directory if name is empty.
+func (d *DirWatcher) setErr(name string, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if err == nil {
+		delete(d.errs, name)
+		return
+	}
+	d.errs[name] = err
+}
+
+// Module returns the version of the named library currently in use, or 0 if there is none; name is the file name, without the directory.
+func (d *DirWatcher) Module(name string) Module {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if p := d.plugins[name]; p != nil {
+		return p.cur
+	}
+	return 0
+}
+
+// Modules returns the versions of every library currently in use, by file name.
+func (d *DirWatcher) Modules() map[string]Module {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	mods := make(map[string]Module, len(d.plugins))
+	for name, p := range d.plugins {
+		if p.cur != 0 {
+			mods[name] = p.cur
+		}
+	}
+	return mods
+}
+
+// Err returns the errors from the most recent attempt to load, reload, or drop each library, and to read the directory, joined together, or nil if they all succeeded.
+// As with a Watcher, a version that fails is not tried again until its file changes again.
+func (d *DirWatcher) Err() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	names := make([]string, 0, len(d.errs))
+	for name := range d.errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	errs := make([]error, len(names))
+	for i, name := range names {
+		errs[i] = d.errs[name]
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops watching and closes every library in use, returning the errors from closing them joined together.
+// Closing a DirWatcher again returns an error wrapping ErrClosed.
+func (d *DirWatcher) Close() error {
+	err := watcherClosedError(d.dir)
+	d.closing.Do(func() {
+		close(d.stop)
+		<-d.done
+
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		var errs []error
+		for name, p := range d.plugins {
+			if p.cur != 0 {
+				errs = append(errs, p.cur.Close())
+			}
+			delete(d.plugins, name)
+		}
+		err = errors.Join(errs...)
+	})
+	return err
+}
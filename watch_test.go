@@ -0,0 +1,103 @@
+// 14 october 2026
+
+//go:build linux
+
+package dl_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andlabs/dl"
+	"github.com/andlabs/dl/dltest"
+)
+
+// copyFile copies the library at from to the file to.
+func copyFile(t *testing.T, from string, to string) {
+	t.Helper()
+	data, err := os.ReadFile(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(to, data, 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcherCloseTwice(t *testing.T) {
+	lib := dltest.Build(t, "int answer(void) { return 42; }\n")
+	w, err := dl.Watch(lib, dl.Now, time.Hour, func(old dl.Module, new dl.Module) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch(%q): %v", lib, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("first Close: %v", err)
+	}
+	if err := w.Close(); !errors.Is(err, dl.ErrClosed) {
+		t.Errorf("second Close error = %v; want ErrClosed", err)
+	}
+}
+
+func TestWatchDir(t *testing.T) {
+	a := dltest.Build(t, "int which(void) { return 1; }\n")
+	b := dltest.Build(t, "int which(void) { return 2; }\n")
+	dir := t.TempDir()
+	copyFile(t, a, filepath.Join(dir, "a.so"))
+	copyFile(t, b, filepath.Join(dir, "b.so"))
+	copyFile(t, b, filepath.Join(dir, "b.txt"))		// doesn't match the pattern
+
+	var mu sync.Mutex
+	events := make(map[string]int)	// +1 for a load, -1 for a drop
+	d, err := dl.WatchDir(dir, "*.so", dl.Now, 10 * time.Millisecond, func(name string, old dl.Module, new dl.Module) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if new == 0 {
+			events[name]--
+		} else if old == 0 {
+			events[name]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WatchDir(%q): %v", dir, err)
+	}
+	mods := d.Modules()
+	if len(mods) != 2 || mods["a.so"] == 0 || mods["b.so"] == 0 {
+		t.Errorf("Modules() = %v; want a.so and b.so", mods)
+	}
+	if err := d.Err(); err != nil {
+		t.Errorf("Err() = %v; want nil", err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "b.so")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for d.Module("b.so") != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if d.Module("b.so") != 0 {
+		t.Errorf("b.so was still loaded after being removed")
+	}
+	if d.Module("a.so") == 0 {
+		t.Errorf("a.so was dropped; want it still loaded")
+	}
+
+	if err := d.Close(); err != nil {
+		t.Errorf("first Close: %v", err)
+	}
+	if err := d.Close(); !errors.Is(err, dl.ErrClosed) {
+		t.Errorf("second Close error = %v; want ErrClosed", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if events["a.so"] != 1 || events["b.so"] != 0 || events["b.txt"] != 0 {
+		t.Errorf("events = %v; want a.so loaded, b.so loaded and dropped, b.txt untouched", events)
+	}
+}
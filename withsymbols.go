@@ -0,0 +1,39 @@
+// 14 october 2026
+
+package dl
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// OpenWithSymbols opens the named library and looks up every one of the given symbols in it, returning them in a map.
+// If any are missing, the library is closed again and the error, which wraps ErrSymbolNotFound, names every missing symbol at once; this is the quickest way to diagnose a plugin built against the wrong version of an interface.
+// As with Symbol, a symbol whose value is nil counts as found.
+func OpenWithSymbols(name string, mode Mode, symbols []string) (Module, map[string]unsafe.Pointer, error) {
+	m, err := Open(name, mode)
+	if err != nil {
+		return 0, nil, err
+	}
+	syms, err := m.Symbols(symbols...)
+	if err != nil {
+		var missing []string
+		for _, s := range symbols {
+			if _, ok := syms[s]; !ok {
+				missing = append(missing, s)
+			}
+		}
+		m.Close()
+		word := "symbols"
+		if len(missing) == 1 {
+			word = "symbol"
+		}
+		return 0, nil, &Error{
+			Op:		"symbol",
+			Library:	name,
+			Err:		ErrSymbolNotFound,
+			Msg:		"dl: " + name + " is missing " + word + " " + strings.Join(missing, ", "),
+		}
+	}
+	return m, syms, nil
+}